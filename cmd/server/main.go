@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
@@ -20,7 +23,13 @@ var (
 	cfg      *config.Config
 	log      *logger.Logger
 	waClient *app.WhatsAppClient
-	errChan  = make(chan error, 2)
+	errChan  = make(chan serviceError, 2) // Service failures, sent with reportError
+
+	// Forwards incoming messages to EVENT_FORWARD_URL; nil when forwarding is disabled
+	eventForwarder *app.EventForwarder
+
+	// Closed once the HTTP server has stopped, so WhatsApp stays connected for final sends
+	serverStopped = make(chan struct{})
 )
 
 func main() {
@@ -40,6 +49,9 @@ func main() {
 	// Initialize and start WhatsApp client
 	startWhatsAppClient(ctx, &wg)
 
+	// Forward incoming messages downstream, if configured
+	startEventForwarder(ctx, &wg)
+
 	// Start the web server
 	startWebServer(ctx, &wg)
 
@@ -57,7 +69,11 @@ func initialize(ctx context.Context) error {
 	}
 
 	// Initialize logger
-	log = logger.New(cfg.Log.Level, cfg.Log.Format, cfg.Log.LogFile)
+	log = logger.New(cfg.Log.Level, cfg.Log.Format, cfg.Log.LogFile, logger.Rotation{
+		MaxSizeMB:  cfg.Log.MaxSizeMB,
+		MaxBackups: cfg.Log.MaxBackups,
+		MaxAgeDays: cfg.Log.MaxAgeDays,
+	})
 	log.Info("Starting WhatsApp Notifier Application")
 
 	// Initialize WhatsApp client
@@ -67,28 +83,174 @@ func initialize(ctx context.Context) error {
 		cfg.Database.DSN,
 		cfg.WhatsApp.LogLevel,
 		cfg.WhatsApp.DeviceName,
+		app.QRConfig{
+			MaxAttempts:  cfg.WhatsApp.QRMaxAttempts,
+			AttemptDelay: cfg.WhatsApp.QRAttemptDelay,
+			Timeout:      cfg.WhatsApp.QRTimeout,
+		},
 		log,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create WhatsApp client: %w", err)
 	}
 
-	// Add event handler
-	waClient.AddEventHandler(app.DefaultEventHandler(log))
+	waClient.SetReadyGracePeriod(cfg.WhatsApp.ReadyGracePeriod)
+	waClient.SetDisableLinkPreviews(cfg.WhatsApp.DisableLinkPreviews)
+	waClient.SetMessageFooter(cfg.WhatsApp.MessageFooter)
+	waClient.SetCheckGroupMembership(cfg.WhatsApp.CheckGroupMembership)
+	waClient.SetSenderAvatars(cfg.WhatsApp.SenderAvatarTTL)
+	waClient.SetMatchDisappearingTimer(cfg.WhatsApp.MatchDisappearing)
+	waClient.SetTypingDelay(cfg.WhatsApp.TypingDelay)
+	waClient.SetSubscribePresence(cfg.WhatsApp.SubscribePresence)
+	waClient.SetDirectoryCache(cfg.WhatsApp.DirectoryCacheTTL, cfg.WhatsApp.DirectoryCacheMax)
+	waClient.Outgoing.SetTTL(cfg.WhatsApp.ReceiptTTL)
+	waClient.SetReconnectConfig(app.ReconnectConfig(cfg.WhatsApp.Reconnect))
+	waClient.SetConnectionHistorySize(cfg.WhatsApp.ConnectionHistorySize)
+	if cfg.WhatsApp.ReconnectAlertJID != "" {
+		waClient.SetReconnectExhaustedHandler(func(attempts int) {
+			alertReconnectExhausted(ctx, attempts)
+		})
+	}
+
+	// Subscribe default event logging
+	app.RegisterDefaultEventHandlers(waClient, log)
+
+	if cfg.Forward.URL != "" {
+		eventForwarder, err = app.NewEventForwarder(app.ForwarderConfig{
+			URL:            cfg.Forward.URL,
+			Timeout:        cfg.Forward.Timeout,
+			MaxRetries:     cfg.Forward.MaxRetries,
+			RetryInterval:  cfg.Forward.RetryInterval,
+			QueueSize:      cfg.Forward.QueueSize,
+			DeadLetterFile: cfg.Forward.DeadLetterFile,
+			Rotation: logger.Rotation{
+				MaxSizeMB:  cfg.Log.MaxSizeMB,
+				MaxBackups: cfg.Log.MaxBackups,
+				MaxAgeDays: cfg.Log.MaxAgeDays,
+			},
+		}, log)
+		if err != nil {
+			return fmt.Errorf("failed to create event forwarder: %w", err)
+		}
+		eventForwarder.Subscribe(waClient)
+	}
+
+	if cfg.WhatsApp.CheckRecipients {
+		checkRecipientsOnConnect(ctx)
+	}
 
 	return nil
 }
 
+// alertReconnectExhausted notifies the alert recipient that reconnection gave up. The
+// connection is usually still down, so a failed alert is sent once it comes back.
+func alertReconnectExhausted(ctx context.Context, attempts int) {
+	message := fmt.Sprintf("🚨 WhatsApp Notifier gave up reconnecting after %d attempts at %s. The session may need re-authentication.",
+		attempts, time.Now().Format(time.RFC1123))
+
+	sendAlert := func() error {
+		sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		_, err := waClient.SendText(sendCtx, cfg.WhatsApp.ReconnectAlertJID, message)
+		return err
+	}
+
+	err := sendAlert()
+	if err == nil {
+		return
+	}
+	log.Warnf("Reconnection alert not sent, retrying once connected: %v", err)
+
+	reconnected := make(chan struct{})
+	var once sync.Once
+	unsubscribe := waClient.Events.OnConnection(func(connected bool) {
+		if connected {
+			once.Do(func() { close(reconnected) })
+		}
+	})
+
+	go func() {
+		defer unsubscribe()
+		select {
+		case <-reconnected:
+		case <-ctx.Done():
+			return
+		}
+		if err := sendAlert(); err != nil {
+			log.Error("Failed to send reconnection alert", err)
+		}
+	}()
+}
+
+// checkRecipientsOnConnect warns about configured recipients that aren't registered on
+// WhatsApp, once the client first connects (right away with a stored session, or after
+// QR authentication). Group JIDs can't be looked up this way and are skipped.
+func checkRecipientsOnConnect(ctx context.Context) {
+	connected := make(chan struct{})
+	var once sync.Once
+	unsubscribe := waClient.Events.OnConnection(func(isConnected bool) {
+		if isConnected {
+			once.Do(func() { close(connected) })
+		}
+	})
+
+	go func() {
+		defer unsubscribe()
+		select {
+		case <-connected:
+		case <-ctx.Done():
+			return
+		}
+
+		settings := make(map[string][]string)
+		numbers := make([]string, 0)
+		for jid, names := range cfg.Recipients() {
+			number, server, _ := strings.Cut(jid, "@")
+			if server != "s.whatsapp.net" {
+				continue
+			}
+			if _, seen := settings[number]; !seen {
+				numbers = append(numbers, number)
+			}
+			settings[number] = append(settings[number], names...)
+		}
+		if len(numbers) == 0 {
+			return
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		statuses, err := waClient.IsOnWhatsApp(checkCtx, numbers)
+		if err != nil {
+			log.Warnf("Failed to check configured recipients: %v", err)
+			return
+		}
+
+		slices.Sort(numbers)
+		unregistered := 0
+		for _, number := range numbers {
+			if !statuses[number].Registered {
+				unregistered++
+				log.Warnf("Recipient %s@s.whatsapp.net (%s) is not registered on WhatsApp", number, strings.Join(settings[number], ", "))
+			}
+		}
+		if unregistered == 0 {
+			log.Infof("All %d configured recipients are registered on WhatsApp", len(numbers))
+		}
+	}()
+}
+
 func startWhatsAppClient(ctx context.Context, wg *sync.WaitGroup) {
 	wg.Go(func() {
 		defer func() {
-			waClient.Disconnect()
+			<-serverStopped
+			waClient.DrainAndDisconnect(cfg.Server.ShutdownTimeout)
 			log.Info("WhatsApp client shutdown complete")
 		}()
 
 		log.Info("Starting WhatsApp client...")
 		if err := waClient.Connect(ctx); err != nil {
-			errChan <- fmt.Errorf("failed to connect to WhatsApp: %w", err)
+			reportError(componentWhatsApp, fmt.Errorf("failed to connect to WhatsApp: %w", err))
 			return
 		}
 
@@ -99,8 +261,21 @@ func startWhatsAppClient(ctx context.Context, wg *sync.WaitGroup) {
 	})
 }
 
+func startEventForwarder(ctx context.Context, wg *sync.WaitGroup) {
+	if eventForwarder == nil {
+		return
+	}
+
+	wg.Go(func() {
+		log.Infof("Forwarding incoming messages to %s", cfg.Forward.URL)
+		eventForwarder.Run(ctx)
+		log.Info("Event forwarder shutdown complete")
+	})
+}
+
 func startWebServer(ctx context.Context, wg *sync.WaitGroup) {
 	wg.Go(func() {
+		defer close(serverStopped)
 		log.Info("Starting HTTP server...")
 
 		// Initialize HTTP handlers
@@ -112,16 +287,53 @@ func startWebServer(ctx context.Context, wg *sync.WaitGroup) {
 			cfg.GitHub.WebhookSecret,
 			cfg.GitHub.Recipient,
 		)
+		httpHandler.SetDebugMode(cfg.Server.DebugMode)
+		httpHandler.SetJSONBufferSize(cfg.Server.JSONBufferSize)
+		httpHandler.SetSendSuccessStatus(cfg.Server.SendSuccessStatus)
+		httpHandler.SetDegradedQueueAge(cfg.Server.DegradedQueueAge)
+		httpHandler.SetHealthProbe(cfg.Server.HealthProbeJID, cfg.Server.HealthProbeTTL)
+		httpHandler.ApplyWebhookConfig(cfg)
+		httpHandler.SetStripJIDDeviceSuffix(cfg.WhatsApp.StripJIDDeviceSuffix)
+		httpHandler.SetMessageFooter(cfg.WhatsApp.MessageFooter)
+		httpHandler.SetRecipientCooldown(cfg.WhatsApp.RecipientCooldown)
+		httpHandler.SetMarkForwarded(cfg.WhatsApp.MarkForwarded)
+		httpHandler.SetRawAPIKeys(cfg.Security.RawAPIKeys)
+		httpHandler.SetMaxMediaSize(int64(cfg.WhatsApp.MaxMediaSize))
+		httpHandler.SetBulkConcurrency(cfg.WhatsApp.BulkConcurrency)
+		httpHandler.SetSendQueueLimits(cfg.WhatsApp.SendConcurrency, cfg.WhatsApp.LowPriorityQueueLimit)
+		httpHandler.SetSeverityEmoji(cfg.Alerts.SeverityEmoji)
+		httpHandler.SetRepoIcons(cfg.Routing.RepoIcons)
+		httpHandler.SetWebhookMaxAge(cfg.Routing.MaxAge)
+		httpHandler.SetStrictSignatureHeaders(cfg.Security.StrictSignatureHeaders)
+		httpHandler.SetConfirmationLinks(cfg.Confirm.Secret, cfg.Confirm.BaseURL, cfg.Confirm.TTL, cfg.Confirm.CallbackURL)
+		httpHandler.SetWebhookDuplicateWindow(cfg.Routing.DuplicateWindow)
+		httpHandler.SetWebhookContentDedupeWindow(cfg.Routing.ContentDedupe)
+		httpHandler.SetWebhookHistorySize(cfg.Routing.HistorySize)
+		httpHandler.SetShowMergeCommits(cfg.Routing.ShowMergeCommits)
+		httpHandler.SetBranchDisplayPattern(cfg.Routing.BranchDisplayPattern)
+		httpHandler.SetBranchFilter(cfg.Routing.BranchFilter)
+		httpHandler.SetPusherSource(cfg.Routing.PusherSource)
+		httpHandler.SetFileChangesMode(cfg.Routing.FileChangesMode)
+		httpHandler.SetDigestInterval(handlers.ProviderGitea, cfg.Gitea.DigestInterval)
+		httpHandler.SetDigestInterval(handlers.ProviderGitHub, cfg.GitHub.DigestInterval)
+		httpHandler.SetDigestInterval(handlers.ProviderBitbucket, cfg.Bitbucket.DigestInterval)
+		httpHandler.SetDigestInterval(handlers.ProviderJenkins, cfg.Jenkins.DigestInterval)
 
 		// Initialize and start HTTP server
 		httpServer := server.New(cfg, httpHandler, log)
 		if err := httpServer.Start(cfg); err != nil {
-			errChan <- fmt.Errorf("failed to start HTTP server: %w", err)
+			reportError(componentHTTPServer, fmt.Errorf("failed to start HTTP server: %w", err))
 			return
 		}
 
-		// Keep the server running until shutdown
-		<-ctx.Done()
+		// Keep the server running until shutdown, reloading on SIGHUP
+		go reloadOnSignal(ctx, httpHandler)
+		select {
+		case <-ctx.Done():
+		case err := <-httpServer.Err():
+			reportError(componentHTTPServer, fmt.Errorf("HTTP server stopped: %w", err))
+			<-ctx.Done()
+		}
 		log.Info("HTTP server shutting down...")
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 		defer shutdownCancel()
@@ -129,17 +341,87 @@ func startWebServer(ctx context.Context, wg *sync.WaitGroup) {
 		if err := httpServer.Shutdown(shutdownCtx); err != nil {
 			log.Error("Error during HTTP server shutdown", err)
 		}
+
+		// Send buffered digests while the WhatsApp client is still connected
+		httpHandler.FlushDigests(shutdownCtx)
+		httpHandler.StopScheduledMessages()
 	})
 }
 
+// reloadOnSignal reloads the reloadable configuration each time the process receives SIGHUP
+func reloadOnSignal(ctx context.Context, httpHandler *handlers.Handler) {
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupChan:
+			log.Info("Received SIGHUP, reloading configuration")
+			// Reload logs the outcome; an invalid configuration leaves the current settings in place
+			_ = httpHandler.Reload()
+		}
+	}
+}
+
+// Components that report failures to waitForShutdown
+const (
+	componentWhatsApp   = "whatsapp_client"
+	componentHTTPServer = "http_server"
+)
+
+// serviceError is a service failure tagged with the component it came from
+type serviceError struct {
+	component string
+	err       error
+}
+
+// logServiceError logs a service failure with its originating component
+func logServiceError(e serviceError) {
+	log.With("component", e.component).Error("Service failed ("+e.component+")", e.err)
+}
+
+// reportError reports a component's failure to waitForShutdown without blocking. Only
+// the first failure triggers shutdown; any beyond the buffer are logged directly.
+func reportError(component string, err error) {
+	e := serviceError{component: component, err: err}
+	select {
+	case errChan <- e:
+	default:
+		logServiceError(e)
+	}
+}
+
 func waitForShutdown(cancel context.CancelFunc, wg *sync.WaitGroup) {
 	// Wait for either service to fail or for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	shutdown(sigChan, cancel, wg)
+
+	log.Info("Application stopped")
+
+	// Close the dead-letter file once nothing can be forwarded anymore
+	if eventForwarder != nil {
+		if err := eventForwarder.Close(); err != nil {
+			log.Error("Error closing event forwarder", err)
+		}
+	}
+
+	// Close logger to flush and close log file
+	if err := log.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error closing logger: %v\n", err)
+	}
+}
+
+// shutdown waits for a service failure or a signal, then stops the services and
+// logs every failure reported, including those reported while stopping
+func shutdown(sigChan <-chan os.Signal, cancel context.CancelFunc, wg *sync.WaitGroup) {
 	select {
-	case err := <-errChan:
-		log.Error("Service failed", err)
+	case e := <-errChan:
+		logServiceError(e)
 	case <-sigChan:
 		log.Info("Received shutdown signal")
 	}
@@ -150,10 +432,8 @@ func waitForShutdown(cancel context.CancelFunc, wg *sync.WaitGroup) {
 	// Wait for all goroutines to finish
 	wg.Wait()
 
-	log.Info("Application stopped")
-
-	// Close logger to flush and close log file
-	if err := log.Close(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error closing logger: %v\n", err)
+	// Log failures reported while shutting down; the services have stopped, so none can follow
+	for len(errChan) > 0 {
+		logServiceError(<-errChan)
 	}
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+)
+
+func TestShutdownLogsEveryServiceFailure(t *testing.T) {
+	// A one-slot buffer forces the second failure down the direct-logging path
+	for _, capacity := range []int{1, cap(errChan)} {
+		t.Run(fmt.Sprintf("buffer %d", capacity), func(t *testing.T) {
+			// Hold the port the HTTP server is configured to use
+			occupied, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer occupied.Close()
+			t.Setenv("SERVER_HOST", "127.0.0.1")
+			t.Setenv("SERVER_PORT", strconv.Itoa(occupied.Addr().(*net.TCPAddr).Port))
+			t.Setenv("API_KEYS", "test-api-key")
+
+			logPath := filepath.Join(t.TempDir(), "app.log")
+			savedCfg, savedLog, savedErrChan, savedStopped := cfg, log, errChan, serverStopped
+			t.Cleanup(func() { cfg, log, errChan, serverStopped = savedCfg, savedLog, savedErrChan, savedStopped })
+			if cfg, err = config.Load(); err != nil {
+				t.Fatal(err)
+			}
+			log = logger.New("info", "json", logPath, logger.Rotation{})
+			errChan = make(chan serviceError, capacity)
+			serverStopped = make(chan struct{})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The HTTP server fails for real on the port conflict. The WhatsApp client
+			// needs a live connection to fail, so its goroutine reports like Connect's error path.
+			var wg sync.WaitGroup
+			startWebServer(ctx, &wg)
+			wg.Go(func() {
+				reportError(componentWhatsApp, errors.New("whatsapp connection refused"))
+				<-ctx.Done()
+			})
+
+			// shutdown only returns once wg.Wait does, which a blocked reportError would prevent
+			done := make(chan struct{})
+			go func() {
+				shutdown(make(chan os.Signal), cancel, &wg)
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("shutdown didn't return; a service is stuck")
+			}
+
+			if err := log.Close(); err != nil {
+				t.Fatal(err)
+			}
+			logged, err := os.ReadFile(logPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				`"component":"whatsapp_client"`,
+				"whatsapp connection refused",
+				`"component":"http_server"`,
+				"address already in use",
+			} {
+				if !strings.Contains(string(logged), want) {
+					t.Errorf("log is missing %s:\n%s", want, logged)
+				}
+			}
+		})
+	}
+}
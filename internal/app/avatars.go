@@ -0,0 +1,99 @@
+package app
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// avatarEntry is a cached profile-picture lookup
+type avatarEntry struct {
+	url       string // Empty when the sender has no (visible) picture
+	fetchedAt time.Time
+}
+
+// avatarCache caches profile-picture URLs per JID for a fixed TTL
+type avatarCache struct {
+	ttl     time.Duration
+	entries map[types.JID]avatarEntry
+	mutex   sync.Mutex
+}
+
+// newAvatarCache creates an avatar cache whose entries expire after ttl
+func newAvatarCache(ttl time.Duration) *avatarCache {
+	return &avatarCache{
+		ttl:     ttl,
+		entries: make(map[types.JID]avatarEntry),
+	}
+}
+
+// get returns a cached URL if the entry hasn't expired
+func (c *avatarCache) get(jid types.JID) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[jid]
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		return "", false
+	}
+	return entry.url, true
+}
+
+// put caches a URL, dropping expired entries so the cache doesn't grow without bound
+func (c *avatarCache) put(jid types.JID, url string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for cached, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) > c.ttl {
+			delete(c.entries, cached)
+		}
+	}
+	c.entries[jid] = avatarEntry{url: url, fetchedAt: now}
+}
+
+// SetSenderAvatars enables resolving sender profile-picture URLs for forwarded
+// events, caching each lookup for ttl. A ttl of zero disables resolution.
+func (w *WhatsAppClient) SetSenderAvatars(ttl time.Duration) {
+	if ttl <= 0 {
+		w.avatars = nil
+		return
+	}
+	w.avatars = newAvatarCache(ttl)
+}
+
+// SenderAvatarURL returns the profile-picture URL of a sender, or "" if avatar
+// resolution is disabled, the sender has no picture, or the lookup fails
+func (w *WhatsAppClient) SenderAvatarURL(sender types.JID) string {
+	if w.avatars == nil {
+		return ""
+	}
+
+	jid := sender.ToNonAD()
+	if url, ok := w.avatars.get(jid); ok {
+		return url
+	}
+
+	info, err := w.Client.GetProfilePictureInfo(jid, &whatsmeow.GetProfilePictureParams{Preview: true})
+	switch {
+	case errors.Is(err, whatsmeow.ErrProfilePictureNotSet), errors.Is(err, whatsmeow.ErrProfilePictureUnauthorized):
+		// Cache the absence too so hidden pictures aren't re-queried on every message
+		w.avatars.put(jid, "")
+		return ""
+	case err != nil:
+		// Transient failures aren't cached
+		w.log.Debugf("Failed to get profile picture for %s: %v", jid, err)
+		return ""
+	}
+
+	url := ""
+	if info != nil {
+		url = info.URL
+	}
+	w.avatars.put(jid, url)
+	return url
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// Connection states recorded in the connection history
+const (
+	StateConnected    = "connected"
+	StateDisconnected = "disconnected"
+	StateLoggedOut    = "logged_out"
+)
+
+// ConnectionTransition is a recorded change of connection state
+type ConnectionTransition struct {
+	State     string
+	Timestamp time.Time
+	Reason    string // Why the state changed, if known
+}
+
+// ConnectionHistory keeps the most recent connection state transitions in a ring buffer
+type ConnectionHistory struct {
+	mutex       sync.RWMutex
+	transitions []ConnectionTransition
+	next        int // Index the next transition is written to
+	full        bool
+}
+
+// NewConnectionHistory creates a history that remembers up to capacity transitions
+func NewConnectionHistory(capacity int) *ConnectionHistory {
+	return &ConnectionHistory{transitions: make([]ConnectionTransition, max(capacity, 0))}
+}
+
+// Record appends a transition, overwriting the oldest once full
+func (h *ConnectionHistory) Record(state, reason string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.transitions) == 0 {
+		return
+	}
+
+	h.transitions[h.next] = ConnectionTransition{State: state, Timestamp: time.Now(), Reason: reason}
+	h.next = (h.next + 1) % len(h.transitions)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// List returns the recorded transitions, newest first
+func (h *ConnectionHistory) List() []ConnectionTransition {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.transitions)
+	}
+
+	list := make([]ConnectionTransition, 0, count)
+	for i := 1; i <= count; i++ {
+		list = append(list, h.transitions[(h.next-i+len(h.transitions))%len(h.transitions)])
+	}
+	return list
+}
+
+// SetConnectionHistorySize replaces the connection history with one holding up to size
+// transitions, discarding those recorded so far; zero disables recording
+func (w *WhatsAppClient) SetConnectionHistorySize(size int) {
+	w.ConnectionHistory = NewConnectionHistory(size)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	apperrors "github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+)
+
+// checkDatabaseWritable verifies that a SQLite database accepts writes, so a
+// read-only mount is reported at startup instead of failing deep inside a send
+func checkDatabaseWritable(ctx context.Context, db *sql.DB, dialect, dsn string) error {
+	if dialect != "sqlite3" {
+		return nil
+	}
+
+	// Creating a table needs a write lock; the transaction is rolled back so nothing is kept
+	tx, err := db.BeginTx(ctx, nil)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS whatsapp_notifier_write_check (id INTEGER)")
+		_ = tx.Rollback()
+	}
+	if err != nil {
+		return databaseError(err, dialect, dsn)
+	}
+	return nil
+}
+
+// databaseError turns read-only and permission failures on a SQLite database
+// into a DatabaseError naming the file; other errors are returned unchanged
+func databaseError(err error, dialect, dsn string) error {
+	if dialect != "sqlite3" || !isReadOnlyError(err) {
+		return err
+	}
+
+	path := sqlitePath(dsn)
+	appErr := apperrors.Wrapf(err, apperrors.ErrCodeDatabaseError,
+		"SQLite database %s is not writable; check that the file and its directory are writable and not on a read-only mount", path)
+	appErr.Details = "DSN path: " + path
+	return appErr
+}
+
+// isReadOnlyError reports whether err means the database can't be opened or written
+func isReadOnlyError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrReadonly, sqlite3.ErrCantOpen, sqlite3.ErrPerm:
+			return true
+		}
+	}
+	return errors.Is(err, fs.ErrPermission) || strings.Contains(err.Error(), "readonly database")
+}
+
+// sqlitePath extracts the file path from a SQLite DSN (e.g. file:mywhatsapp.db?_foreign_keys=on)
+func sqlitePath(dsn string) string {
+	path := strings.TrimPrefix(dsn, "file:")
+	if idx := strings.Index(path, "?"); idx != -1 {
+		path = path[:idx]
+	}
+	return path
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+)
+
+// directoryRefreshTimeout bounds a background refresh of a cached list
+const directoryRefreshTimeout = 30 * time.Second
+
+// cachedList caches the result of an expensive list lookup (joined groups, contacts).
+// Once the TTL passes the cached list is still served while a refresh runs in the
+// background; after invalidation the next lookup fetches synchronously.
+type cachedList[T any] struct {
+	name       string // For logs
+	ttl        time.Duration
+	maxEntries int // Lists longer than this aren't cached (0 caches any size)
+	fetch      func(context.Context) (T, error)
+	size       func(T) int
+	log        *logger.Logger
+
+	mutex      sync.Mutex
+	value      T
+	valid      bool
+	fetchedAt  time.Time
+	refreshing bool
+	generation uint64 // Bumped on invalidation so in-flight fetches don't store stale lists
+}
+
+// get returns the cached list, fetching it if there is none
+func (c *cachedList[T]) get(ctx context.Context) (T, error) {
+	c.mutex.Lock()
+	if c.valid {
+		value := c.value
+		if time.Since(c.fetchedAt) >= c.ttl && !c.refreshing {
+			c.refreshing = true
+			go c.refresh(c.generation)
+		}
+		c.mutex.Unlock()
+		return value, nil
+	}
+	generation := c.generation
+	c.mutex.Unlock()
+
+	value, err := c.fetch(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.store(generation, value)
+	return value, nil
+}
+
+// refresh re-fetches the list in the background
+func (c *cachedList[T]) refresh(generation uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), directoryRefreshTimeout)
+	defer cancel()
+
+	value, err := c.fetch(ctx)
+
+	c.mutex.Lock()
+	c.refreshing = false
+	c.mutex.Unlock()
+
+	if err != nil {
+		c.log.Warnf("Failed to refresh cached %s: %v", c.name, err)
+		return
+	}
+	c.store(generation, value)
+}
+
+// store caches a fetched list unless it was invalidated meanwhile or is over the size cap
+func (c *cachedList[T]) store(generation uint64, value T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if size := c.size(value); c.maxEntries > 0 && size > c.maxEntries {
+		c.log.Debugf("Not caching %d %s (cap is %d)", size, c.name, c.maxEntries)
+		c.valid = false
+		return
+	}
+
+	c.value = value
+	c.valid = true
+	c.fetchedAt = time.Now()
+}
+
+// invalidate drops the cached list so the next lookup fetches a fresh one
+func (c *cachedList[T]) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var zero T
+	c.value = zero
+	c.valid = false
+	c.generation++
+}
+
+// SetDirectoryCache caches the joined-groups and contacts lists for ttl, refreshing them
+// in the background once expired. Lists with more than maxEntries entries aren't cached
+// (0 caches any size). A ttl of zero disables caching.
+func (w *WhatsAppClient) SetDirectoryCache(ttl time.Duration, maxEntries int) {
+	if ttl <= 0 {
+		w.groupsCache = nil
+		w.contactsCache = nil
+		return
+	}
+
+	w.groupsCache = &cachedList[[]*types.GroupInfo]{
+		name:       "joined groups",
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		fetch:      w.fetchJoinedGroups,
+		size:       func(groups []*types.GroupInfo) int { return len(groups) },
+		log:        w.log,
+	}
+	w.contactsCache = &cachedList[map[types.JID]types.ContactInfo]{
+		name:       "contacts",
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		fetch:      w.fetchContacts,
+		size:       func(contacts map[types.JID]types.ContactInfo) int { return len(contacts) },
+		log:        w.log,
+	}
+}
+
+// handleDirectoryEvents invalidates the cached lists when groups or contacts change
+func (w *WhatsAppClient) handleDirectoryEvents(evt interface{}) {
+	groups, contacts := w.groupsCache, w.contactsCache
+	if groups == nil || contacts == nil {
+		return
+	}
+
+	switch evt.(type) {
+	case *events.JoinedGroup, *events.GroupInfo:
+		groups.invalidate()
+	case *events.Contact, *events.PushName, *events.BusinessName:
+		contacts.invalidate()
+	case *events.Connected:
+		// Changes made while disconnected produced no events
+		groups.invalidate()
+		contacts.invalidate()
+	}
+}
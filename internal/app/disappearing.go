@@ -0,0 +1,138 @@
+package app
+
+import (
+	"sync"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// disappearingTimers remembers the disappearing-message timer of each chat, in seconds
+type disappearingTimers struct {
+	timers map[types.JID]uint32
+	mutex  sync.RWMutex
+}
+
+// get returns the known timer of a chat
+func (d *disappearingTimers) get(chat types.JID) (uint32, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	timer, ok := d.timers[chat]
+	return timer, ok
+}
+
+// set records the timer of a chat; zero means disappearing messages are off
+func (d *disappearingTimers) set(chat types.JID, timer uint32) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.timers[chat] = timer
+}
+
+// SetMatchDisappearingTimer controls whether outgoing messages carry the chat's
+// disappearing-message timer when a request doesn't set one
+func (w *WhatsAppClient) SetMatchDisappearingTimer(enabled bool) {
+	if !enabled {
+		w.disappearing = nil
+		return
+	}
+	w.disappearing = &disappearingTimers{timers: make(map[types.JID]uint32)}
+}
+
+// handleDisappearingEvents learns chat timers from setting changes and incoming messages.
+// WhatsApp has no query for a private chat's timer, so it is only known once seen.
+func (w *WhatsAppClient) handleDisappearingEvents(evt interface{}) {
+	timers := w.disappearing
+	if timers == nil {
+		return
+	}
+
+	switch v := evt.(type) {
+	case *events.GroupInfo:
+		if v.Ephemeral != nil {
+			timers.set(v.JID, groupDisappearingTimer(v.Ephemeral))
+		}
+
+	case *events.Message:
+		if setting := v.Message.GetProtocolMessage(); setting.GetType() == waE2E.ProtocolMessage_EPHEMERAL_SETTING {
+			timers.set(v.Info.Chat, setting.GetEphemeralExpiration())
+			return
+		}
+		if v.IsEphemeral {
+			if expiration := messageContextInfo(v.Message).GetExpiration(); expiration > 0 {
+				timers.set(v.Info.Chat, expiration)
+			}
+		}
+	}
+}
+
+// disappearingTimer returns the chat's timer in seconds, or zero when matching is
+// disabled or the timer is unknown. Group timers are fetched on first use.
+func (w *WhatsAppClient) disappearingTimer(chat types.JID) uint32 {
+	timers := w.disappearing
+	if timers == nil {
+		return 0
+	}
+
+	if timer, ok := timers.get(chat); ok || chat.Server != types.GroupServer {
+		return timer
+	}
+
+	info, err := w.Client.GetGroupInfo(chat)
+	if err != nil {
+		w.log.Warnf("Failed to look up disappearing-message timer of %s: %v", chat, err)
+		return 0
+	}
+
+	timer := groupDisappearingTimer(&info.GroupEphemeral)
+	timers.set(chat, timer)
+	return timer
+}
+
+// resolveDisappearingTimer returns the per-request timer if given, otherwise the chat's timer
+func (w *WhatsAppClient) resolveDisappearingTimer(chat types.JID, requested *uint32) uint32 {
+	if requested != nil {
+		return *requested
+	}
+	return w.disappearingTimer(chat)
+}
+
+// groupDisappearingTimer returns a group's timer in seconds, or zero when it is off
+func groupDisappearingTimer(ephemeral *types.GroupEphemeral) uint32 {
+	if !ephemeral.IsEphemeral {
+		return 0
+	}
+	return ephemeral.DisappearingTimer
+}
+
+// messageContextInfo returns the context info of the message types this service sends or reads
+func messageContextInfo(msg *waE2E.Message) *waE2E.ContextInfo {
+	switch {
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetContextInfo()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetContextInfo()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetContextInfo()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetContextInfo()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetContextInfo()
+	}
+	return nil
+}
+
+// disappearingContextInfo returns context info marking a message as disappearing after timer seconds
+func disappearingContextInfo(info *waE2E.ContextInfo, timer uint32) *waE2E.ContextInfo {
+	if info == nil {
+		info = &waE2E.ContextInfo{}
+	}
+	info.Expiration = proto.Uint32(timer)
+	info.DisappearingMode = &waE2E.DisappearingMode{
+		Initiator: waE2E.DisappearingMode_CHANGED_IN_CHAT.Enum(),
+	}
+	return info
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrShuttingDown is returned for sends started after the client began draining for shutdown
+var ErrShuttingDown = errors.New("client is shutting down")
+
+// beginSend registers an in-flight send, failing once the client is draining.
+// Each successful call must be paired with endSend.
+func (w *WhatsAppClient) beginSend() error {
+	w.inFlightMutex.Lock()
+	defer w.inFlightMutex.Unlock()
+
+	if w.draining {
+		return ErrShuttingDown
+	}
+	w.inFlight.Add(1)
+	return nil
+}
+
+// endSend marks an in-flight send as finished
+func (w *WhatsAppClient) endSend() {
+	w.inFlight.Done()
+}
+
+// DrainAndDisconnect stops accepting new sends, waits up to timeout for sends
+// already in flight to finish, then disconnects from WhatsApp
+func (w *WhatsAppClient) DrainAndDisconnect(timeout time.Duration) {
+	w.inFlightMutex.Lock()
+	w.draining = true
+	w.inFlightMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.log.Info("All in-flight sends finished")
+	case <-time.After(timeout):
+		w.log.Warnf("Disconnecting with sends still in flight after waiting %s", timeout)
+	}
+
+	w.Disconnect()
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"sync"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// EventBus fans out WhatsApp events from a single client handler to typed subscribers.
+// Subscribers are called synchronously, in subscription order.
+type EventBus struct {
+	mutex  sync.RWMutex
+	nextID int
+
+	eventSubs      []subscription[interface{}]
+	messageSubs    []subscription[*events.Message]
+	receiptSubs    []subscription[*events.Receipt]
+	connectionSubs []subscription[bool]
+}
+
+// subscription is a registered handler with an ID used for unsubscribing
+type subscription[T any] struct {
+	id      int
+	handler func(T)
+}
+
+// NewEventBus creates an empty event bus
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// OnEvent subscribes to every event and returns a function that unsubscribes
+func (b *EventBus) OnEvent(handler func(interface{})) func() {
+	return subscribe(b, &b.eventSubs, handler)
+}
+
+// OnMessage subscribes to incoming messages and returns a function that unsubscribes
+func (b *EventBus) OnMessage(handler func(*events.Message)) func() {
+	return subscribe(b, &b.messageSubs, handler)
+}
+
+// OnReceipt subscribes to delivery/read receipts and returns a function that unsubscribes
+func (b *EventBus) OnReceipt(handler func(*events.Receipt)) func() {
+	return subscribe(b, &b.receiptSubs, handler)
+}
+
+// OnConnection subscribes to connect (true) and disconnect (false) transitions
+// and returns a function that unsubscribes
+func (b *EventBus) OnConnection(handler func(connected bool)) func() {
+	return subscribe(b, &b.connectionSubs, handler)
+}
+
+// Dispatch delivers an event to all matching subscribers
+func (b *EventBus) Dispatch(evt interface{}) {
+	b.mutex.RLock()
+	eventSubs := b.eventSubs
+	messageSubs := b.messageSubs
+	receiptSubs := b.receiptSubs
+	connectionSubs := b.connectionSubs
+	b.mutex.RUnlock()
+
+	for _, sub := range eventSubs {
+		sub.handler(evt)
+	}
+
+	switch v := evt.(type) {
+	case *events.Message:
+		for _, sub := range messageSubs {
+			sub.handler(v)
+		}
+	case *events.Receipt:
+		for _, sub := range receiptSubs {
+			sub.handler(v)
+		}
+	case *events.Connected:
+		for _, sub := range connectionSubs {
+			sub.handler(true)
+		}
+	case *events.Disconnected:
+		for _, sub := range connectionSubs {
+			sub.handler(false)
+		}
+	}
+}
+
+// subscribe appends a handler to a subscriber list and returns its unsubscribe function.
+// Lists are copied on write so Dispatch can iterate a snapshot without holding the lock.
+func subscribe[T any](b *EventBus, subs *[]subscription[T], handler func(T)) func() {
+	b.mutex.Lock()
+	b.nextID++
+	id := b.nextID
+	updated := make([]subscription[T], len(*subs), len(*subs)+1)
+	copy(updated, *subs)
+	*subs = append(updated, subscription[T]{id: id, handler: handler})
+	b.mutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+
+			updated := make([]subscription[T], 0, len(*subs))
+			for _, sub := range *subs {
+				if sub.id != id {
+					updated = append(updated, sub)
+				}
+			}
+			*subs = updated
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// maxForwardRetryInterval caps the wait between forwarding attempts
+const maxForwardRetryInterval = time.Minute
+
+// ForwarderConfig configures forwarding of incoming messages to a downstream endpoint
+type ForwarderConfig struct {
+	URL           string        // Endpoint each incoming message is POSTed to
+	Timeout       time.Duration // Timeout of each delivery attempt
+	MaxRetries    int           // Retries after a failed attempt before the event is dead-lettered
+	RetryInterval time.Duration // Wait before the first retry, doubled after each further failure
+	QueueSize     int           // Events buffered for delivery; events beyond it are dead-lettered
+
+	DeadLetterFile string          // JSONL file for undeliverable events (empty drops them with an error log)
+	Rotation       logger.Rotation // Rotation of the dead-letter file
+}
+
+// EventForwarder POSTs incoming messages as JSON to a downstream endpoint, in order,
+// retrying failed deliveries. Events that still can't be delivered are appended to
+// the dead-letter file so they can be replayed later.
+type EventForwarder struct {
+	config ForwarderConfig
+	client *http.Client
+	log    *logger.Logger
+	queue  chan models.IncomingMessageEvent
+
+	// Resolves the sender's profile-picture URL before delivery; nil leaves it out
+	senderAvatar func(sender types.JID) string
+
+	// Dead-letter file, and whether Run has stopped delivering
+	mutex       sync.Mutex
+	deadLetters io.WriteCloser
+	stopped     bool
+}
+
+// NewEventForwarder creates a forwarder, opening its dead-letter file if one is configured
+func NewEventForwarder(config ForwarderConfig, log *logger.Logger) (*EventForwarder, error) {
+	f := &EventForwarder{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		log:    log,
+		queue:  make(chan models.IncomingMessageEvent, max(config.QueueSize, 1)),
+	}
+
+	if config.DeadLetterFile != "" {
+		deadLetters, err := logger.OpenRotatingFile(config.DeadLetterFile, config.Rotation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+		}
+		f.deadLetters = deadLetters
+	}
+	return f, nil
+}
+
+// Subscribe forwards the client's incoming messages, with the sender's profile-picture
+// URL when the client resolves them, and returns a function that unsubscribes.
+// It must be called before Run.
+func (f *EventForwarder) Subscribe(w *WhatsAppClient) func() {
+	f.senderAvatar = w.SenderAvatarURL
+	return w.Events.OnMessage(func(v *events.Message) {
+		if v.Info.IsFromMe {
+			return
+		}
+		f.Enqueue(incomingMessageEvent(v))
+	})
+}
+
+// Enqueue queues an event for delivery without blocking. Events that don't fit in the
+// queue, or arrive after Run has stopped, are dead-lettered straight away.
+func (f *EventForwarder) Enqueue(event models.IncomingMessageEvent) {
+	f.mutex.Lock()
+	stopped := f.stopped
+	f.mutex.Unlock()
+	if stopped {
+		f.deadLetter(event, fmt.Errorf("forwarder stopped"), 0)
+		return
+	}
+
+	select {
+	case f.queue <- event:
+	default:
+		f.deadLetter(event, fmt.Errorf("forward queue full"), 0)
+	}
+}
+
+// Run delivers queued events until ctx is done, then dead-letters the events still queued
+func (f *EventForwarder) Run(ctx context.Context) {
+	for {
+		select {
+		case event := <-f.queue:
+			f.deliver(ctx, event)
+		case <-ctx.Done():
+			f.mutex.Lock()
+			f.stopped = true
+			f.mutex.Unlock()
+
+			for {
+				select {
+				case event := <-f.queue:
+					f.deadLetter(event, fmt.Errorf("forwarder stopped"), 0)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver POSTs an event, retrying with backoff, and dead-letters it once retries are exhausted
+func (f *EventForwarder) deliver(ctx context.Context, event models.IncomingMessageEvent) {
+	// Resolved here rather than in the event handler, since a lookup may call WhatsApp
+	if f.senderAvatar != nil && event.SenderAvatarURL == "" {
+		if sender, err := types.ParseJID(event.Sender); err == nil {
+			event.SenderAvatarURL = f.senderAvatar(sender)
+		}
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		f.deadLetter(event, err, 0)
+		return
+	}
+
+	interval := f.config.RetryInterval
+	attempts := 0
+	for {
+		attempts++
+		err = f.post(ctx, body)
+		if err == nil {
+			return
+		}
+		if attempts > f.config.MaxRetries {
+			break
+		}
+
+		f.log.Warnf("Forwarding message %s failed (attempt %d), retrying in %s: %v", event.MessageID, attempts, interval, err)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			f.deadLetter(event, fmt.Errorf("forwarder stopped after: %w", err), attempts)
+			return
+		}
+		interval = min(interval*2, maxForwardRetryInterval)
+	}
+
+	f.deadLetter(event, err, attempts)
+}
+
+// post makes one delivery attempt; any response other than 2xx is a failure
+func (f *EventForwarder) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// deadLetter appends an undeliverable event to the dead-letter file as one JSON line,
+// or logs it as lost when there is no dead-letter file
+func (f *EventForwarder) deadLetter(event models.IncomingMessageEvent, cause error, attempts int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.deadLetters == nil {
+		f.log.Errorf("Dropped incoming message %s from %s that couldn't be forwarded: %v", event.MessageID, event.Sender, cause)
+		return
+	}
+
+	line, err := json.Marshal(models.DeadLetterEntry{
+		Event:    event,
+		Error:    cause.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().Unix(),
+	})
+	if err == nil {
+		_, err = f.deadLetters.Write(append(line, '\n'))
+	}
+	if err != nil {
+		f.log.Errorf("Failed to dead-letter incoming message %s from %s (%v): %v", event.MessageID, event.Sender, cause, err)
+		return
+	}
+	f.log.Warnf("Dead-lettered incoming message %s from %s: %v", event.MessageID, event.Sender, cause)
+}
+
+// Close closes the dead-letter file; events dead-lettered afterwards are logged as lost
+func (f *EventForwarder) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.deadLetters == nil {
+		return nil
+	}
+	err := f.deadLetters.Close()
+	f.deadLetters = nil
+	return err
+}
+
+// incomingMessageEvent converts a received message into its forwarded form
+func incomingMessageEvent(v *events.Message) models.IncomingMessageEvent {
+	return models.IncomingMessageEvent{
+		MessageID: v.Info.ID,
+		Chat:      v.Info.Chat.String(),
+		Sender:    v.Info.Sender.String(),
+		PushName:  v.Info.PushName,
+		IsGroup:   v.Info.IsGroup,
+		Text:      messagePreview(v.Message),
+		Timestamp: v.Info.Timestamp.Unix(),
+	}
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// newTestForwarder creates a forwarder with logging disabled
+func newTestForwarder(t *testing.T, config ForwarderConfig) *EventForwarder {
+	t.Helper()
+
+	f, err := NewEventForwarder(config, logger.New("disabled", "json", "", logger.Rotation{}))
+	if err != nil {
+		t.Fatalf("NewEventForwarder() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+	return f
+}
+
+// readDeadLetters returns the entries written to a dead-letter file
+func readDeadLetters(t *testing.T, path string) []models.DeadLetterEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	entries := make([]models.DeadLetterEntry, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry models.DeadLetterEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("dead-letter line %q isn't JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestEventForwarderRetriesThenDelivers(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		var event models.IncomingMessageEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil || event.MessageID != "msg-1" {
+			t.Errorf("forwarded body = %+v (%v), want message msg-1", event, err)
+		}
+	}))
+	defer server.Close()
+
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+	f := newTestForwarder(t, ForwarderConfig{
+		URL:            server.URL,
+		Timeout:        time.Second,
+		MaxRetries:     3,
+		RetryInterval:  time.Millisecond,
+		QueueSize:      10,
+		DeadLetterFile: deadLetterFile,
+	})
+	f.deliver(context.Background(), models.IncomingMessageEvent{MessageID: "msg-1"})
+
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("endpoint called %d times, want 3", got)
+	}
+	if entries := readDeadLetters(t, deadLetterFile); len(entries) != 0 {
+		t.Errorf("dead-letter file has %d entries after a successful retry, want 0", len(entries))
+	}
+}
+
+func TestEventForwarderIncludesSenderAvatar(t *testing.T) {
+	received := make(chan models.IncomingMessageEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.IncomingMessageEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("forwarded body isn't JSON: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	f := newTestForwarder(t, ForwarderConfig{URL: server.URL, Timeout: time.Second, QueueSize: 1})
+	f.senderAvatar = func(sender types.JID) string {
+		return "https://pps.whatsapp.net/" + sender.User + ".jpg"
+	}
+	f.deliver(context.Background(), models.IncomingMessageEvent{MessageID: "msg-1", Sender: "111@s.whatsapp.net"})
+
+	if event := <-received; event.SenderAvatarURL != "https://pps.whatsapp.net/111.jpg" {
+		t.Errorf("sender_avatar_url = %q, want the resolved URL", event.SenderAvatarURL)
+	}
+}
+
+func TestEventForwarderDeadLettersAfterRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	deadLetterFile := filepath.Join(t.TempDir(), "forward", "dead-letters.jsonl")
+	f := newTestForwarder(t, ForwarderConfig{
+		URL:            server.URL,
+		Timeout:        time.Second,
+		MaxRetries:     2,
+		RetryInterval:  time.Millisecond,
+		QueueSize:      10,
+		DeadLetterFile: deadLetterFile,
+	})
+	f.deliver(context.Background(), models.IncomingMessageEvent{MessageID: "msg-1", Sender: "111@s.whatsapp.net", Text: "first"})
+	f.deliver(context.Background(), models.IncomingMessageEvent{MessageID: "msg-2", Sender: "222@s.whatsapp.net", Text: "second"})
+
+	if got := attempts.Load(); got != 6 {
+		t.Errorf("endpoint called %d times, want 6", got)
+	}
+	entries := readDeadLetters(t, deadLetterFile)
+	if len(entries) != 2 {
+		t.Fatalf("dead-letter file has %d entries, want 2", len(entries))
+	}
+	for i, want := range []string{"msg-1", "msg-2"} {
+		entry := entries[i]
+		if entry.Event.MessageID != want {
+			t.Errorf("entry %d is for %s, want %s", i, entry.Event.MessageID, want)
+		}
+		if entry.Attempts != 3 {
+			t.Errorf("entry %d has %d attempts, want 3", i, entry.Attempts)
+		}
+		if entry.Error == "" || entry.FailedAt == 0 {
+			t.Errorf("entry %d = %+v, want the error and failure time", i, entry)
+		}
+	}
+}
+
+func TestEventForwarderDeadLettersQueueOnStop(t *testing.T) {
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+	f := newTestForwarder(t, ForwarderConfig{
+		URL:            "http://127.0.0.1:0",
+		Timeout:        time.Second,
+		RetryInterval:  time.Millisecond,
+		QueueSize:      1,
+		DeadLetterFile: deadLetterFile,
+	})
+
+	// The second event doesn't fit in the queue
+	f.Enqueue(models.IncomingMessageEvent{MessageID: "msg-1"})
+	f.Enqueue(models.IncomingMessageEvent{MessageID: "msg-2"})
+
+	// Stopping dead-letters the queued event, and later events go straight to the file
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f.Run(ctx)
+	f.Enqueue(models.IncomingMessageEvent{MessageID: "msg-3"})
+
+	entries := readDeadLetters(t, deadLetterFile)
+	if len(entries) != 3 {
+		t.Fatalf("dead-letter file has %d entries, want 3", len(entries))
+	}
+	for i, want := range []string{"msg-2", "msg-1", "msg-3"} {
+		if entries[i].Event.MessageID != want {
+			t.Errorf("entry %d is for %s, want %s", i, entries[i].Event.MessageID, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// SendImage uploads an image and sends it with an optional caption to the specified JID.
+// The content is streamed through a temporary file rather than held in memory.
+// It returns the message ID.
+func (w *WhatsAppClient) SendImage(ctx context.Context, toJID string, content io.Reader, mimetype, caption string) (string, error) {
+	jid, err := w.resolveRecipient(ctx, toJID)
+	if err != nil {
+		return "", err
+	}
+
+	upload, err := w.Client.UploadReader(ctx, content, nil, whatsmeow.MediaImage)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	msg := &waE2E.Message{
+		ImageMessage: &waE2E.ImageMessage{
+			Caption:       proto.String(caption),
+			Mimetype:      proto.String(mimetype),
+			URL:           proto.String(upload.URL),
+			DirectPath:    proto.String(upload.DirectPath),
+			MediaKey:      upload.MediaKey,
+			FileEncSHA256: upload.FileEncSHA256,
+			FileSHA256:    upload.FileSHA256,
+			FileLength:    proto.Uint64(upload.FileLength),
+		},
+	}
+
+	if timer := w.disappearingTimer(jid); timer > 0 {
+		msg.ImageMessage.ContextInfo = disappearingContextInfo(nil, timer)
+	}
+
+	resp, err := w.sendMessage(ctx, jid, msg)
+	return resp.ID, err
+}
+
+// SendDocument uploads a file and sends it as a document to the specified JID.
+// The content is streamed through a temporary file rather than held in memory.
+// It returns the message ID.
+func (w *WhatsAppClient) SendDocument(ctx context.Context, toJID string, content io.Reader, filename, mimetype string) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("document filename is required")
+	}
+
+	jid, err := w.resolveRecipient(ctx, toJID)
+	if err != nil {
+		return "", err
+	}
+
+	upload, err := w.Client.UploadReader(ctx, content, nil, whatsmeow.MediaDocument)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload document: %w", err)
+	}
+
+	msg := &waE2E.Message{
+		DocumentMessage: &waE2E.DocumentMessage{
+			Title:         proto.String(filename),
+			FileName:      proto.String(filename),
+			Mimetype:      proto.String(mimetype),
+			URL:           proto.String(upload.URL),
+			DirectPath:    proto.String(upload.DirectPath),
+			MediaKey:      upload.MediaKey,
+			FileEncSHA256: upload.FileEncSHA256,
+			FileSHA256:    upload.FileSHA256,
+			FileLength:    proto.Uint64(upload.FileLength),
+		},
+	}
+
+	if timer := w.disappearingTimer(jid); timer > 0 {
+		msg.DocumentMessage.ContextInfo = disappearingContextInfo(nil, timer)
+	}
+
+	resp, err := w.sendMessage(ctx, jid, msg)
+	return resp.ID, err
+}
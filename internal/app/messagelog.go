@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// maxBodyPreview is the number of characters of a message body kept in the audit log
+const maxBodyPreview = 200
+
+// Audit log statuses
+const (
+	SendStatusSent   = "sent"
+	SendStatusFailed = "failed"
+)
+
+// SentMessage is an entry in the outbound message audit log
+type SentMessage struct {
+	ID          int64
+	MessageID   string
+	To          string
+	BodyPreview string
+	Status      string
+	Error       string
+	Timestamp   time.Time
+}
+
+// MessageLog persists an audit record of every send attempt in the database
+type MessageLog struct {
+	db *sql.DB
+}
+
+// NewMessageLog creates the sent_messages table if needed and returns a log backed by db
+func NewMessageLog(ctx context.Context, db *sql.DB, dialect string) (*MessageLog, error) {
+	idColumn := "INTEGER PRIMARY KEY AUTOINCREMENT"
+	if dialect == "postgres" || dialect == "pgx" {
+		idColumn = "BIGSERIAL PRIMARY KEY"
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS sent_messages (
+		id           %s,
+		message_id   TEXT NOT NULL,
+		to_jid       TEXT NOT NULL,
+		body_preview TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		error        TEXT NOT NULL,
+		timestamp    BIGINT NOT NULL
+	)`, idColumn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sent_messages table: %w", err)
+	}
+
+	return &MessageLog{db: db}, nil
+}
+
+// Record inserts an audit entry for a send attempt; sendErr is nil on success
+func (l *MessageLog) Record(ctx context.Context, messageID, to, body string, sendErr error) error {
+	status, errText := SendStatusSent, ""
+	if sendErr != nil {
+		status, errText = SendStatusFailed, sendErr.Error()
+	}
+
+	_, err := l.db.ExecContext(ctx,
+		`INSERT INTO sent_messages (message_id, to_jid, body_preview, status, error, timestamp) VALUES ($1, $2, $3, $4, $5, $6)`,
+		messageID, to, truncatePreview(body), status, errText, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to record sent message: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit audit entries, newest first
+func (l *MessageLog) Recent(ctx context.Context, limit int) ([]SentMessage, error) {
+	rows, err := l.db.QueryContext(ctx,
+		`SELECT id, message_id, to_jid, body_preview, status, error, timestamp FROM sent_messages ORDER BY id DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sent messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []SentMessage
+	for rows.Next() {
+		var msg SentMessage
+		var timestamp int64
+		if err := rows.Scan(&msg.ID, &msg.MessageID, &msg.To, &msg.BodyPreview, &msg.Status, &msg.Error, &timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan sent message: %w", err)
+		}
+		msg.Timestamp = time.Unix(timestamp, 0)
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// messagePreview returns the text of a message for the audit log: the body for
+// text messages, or the caption/filename for media. Media bytes are never included.
+func messagePreview(msg *waE2E.Message) string {
+	switch {
+	case msg.GetConversation() != "":
+		return msg.GetConversation()
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetText()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetCaption()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetFileName()
+	case msg.GetReactionMessage() != nil:
+		return msg.GetReactionMessage().GetText()
+	}
+	return ""
+}
+
+// truncatePreview shortens a body to maxBodyPreview characters without splitting a rune
+func truncatePreview(body string) string {
+	if utf8.RuneCountInString(body) <= maxBodyPreview {
+		return body
+	}
+	return string([]rune(body)[:maxBodyPreview])
+}
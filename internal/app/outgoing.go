@@ -0,0 +1,170 @@
+package app
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// MessageStatus represents the delivery state of an outgoing message
+type MessageStatus string
+
+const (
+	StatusSent      MessageStatus = "sent"
+	StatusDelivered MessageStatus = "delivered"
+	StatusRead      MessageStatus = "read"
+	StatusPlayed    MessageStatus = "played"
+)
+
+// statusRank orders statuses so a message never moves back to an earlier state
+var statusRank = map[MessageStatus]int{
+	StatusSent:      0,
+	StatusDelivered: 1,
+	StatusRead:      2,
+	StatusPlayed:    3,
+}
+
+// IsValidMessageStatus reports whether s is a known message status
+func IsValidMessageStatus(s string) bool {
+	_, ok := statusRank[MessageStatus(s)]
+	return ok
+}
+
+// OutgoingMessage holds the tracked state of a sent message
+type OutgoingMessage struct {
+	ID        string
+	To        string
+	Status    MessageStatus
+	SentAt    time.Time
+	UpdatedAt time.Time
+}
+
+// OutgoingTracker keeps the delivery state of the most recent outgoing messages
+type OutgoingTracker struct {
+	mutex    sync.RWMutex
+	messages map[string]*OutgoingMessage
+	order    []string // Message IDs, oldest first
+	capacity int
+	ttl      time.Duration // Forget messages sent longer ago than this (0 keeps them until evicted by capacity)
+}
+
+// NewOutgoingTracker creates a tracker that remembers up to capacity messages
+func NewOutgoingTracker(capacity int) *OutgoingTracker {
+	return &OutgoingTracker{
+		messages: make(map[string]*OutgoingMessage),
+		capacity: capacity,
+	}
+}
+
+// SetTTL sets how long a sent message is tracked; zero keeps messages until the tracker is full
+func (t *OutgoingTracker) SetTTL(ttl time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.ttl = ttl
+}
+
+// expired reports whether a message was sent longer than the TTL ago
+func (t *OutgoingTracker) expired(msg *OutgoingMessage, now time.Time) bool {
+	return t.ttl > 0 && now.Sub(msg.SentAt) > t.ttl
+}
+
+// Record starts tracking a sent message, evicting expired messages and the oldest one if full
+func (t *OutgoingTracker) Record(id, to string, sentAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.messages[id]; exists {
+		return
+	}
+
+	now := time.Now()
+	for len(t.order) > 0 && t.expired(t.messages[t.order[0]], now) {
+		delete(t.messages, t.order[0])
+		t.order = t.order[1:]
+	}
+
+	if len(t.order) >= t.capacity {
+		oldest := t.order[0]
+		t.order = t.order[1:]
+		delete(t.messages, oldest)
+	}
+
+	t.messages[id] = &OutgoingMessage{
+		ID:        id,
+		To:        to,
+		Status:    StatusSent,
+		SentAt:    sentAt,
+		UpdatedAt: sentAt,
+	}
+	t.order = append(t.order, id)
+}
+
+// HandleReceipt advances the status of tracked messages referenced by a receipt
+func (t *OutgoingTracker) HandleReceipt(evt *events.Receipt) {
+	// Receipts we send for incoming messages are not about our outgoing messages
+	if evt.IsFromMe {
+		return
+	}
+
+	var status MessageStatus
+	switch evt.Type {
+	case types.ReceiptTypeDelivered:
+		status = StatusDelivered
+	case types.ReceiptTypeRead:
+		status = StatusRead
+	case types.ReceiptTypePlayed:
+		status = StatusPlayed
+	default:
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, id := range evt.MessageIDs {
+		msg, ok := t.messages[id]
+		if !ok || statusRank[status] <= statusRank[msg.Status] {
+			continue
+		}
+		msg.Status = status
+		msg.UpdatedAt = evt.Timestamp
+	}
+}
+
+// Get returns the tracked state of a sent message
+func (t *OutgoingTracker) Get(id string) (OutgoingMessage, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	msg, ok := t.messages[id]
+	if !ok || t.expired(msg, time.Now()) {
+		return OutgoingMessage{}, false
+	}
+	return *msg, true
+}
+
+// List returns tracked messages sent at or after since, newest first.
+// An empty status matches all statuses.
+func (t *OutgoingTracker) List(since time.Time, status MessageStatus) []OutgoingMessage {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	now := time.Now()
+	result := make([]OutgoingMessage, 0)
+	for _, msg := range t.messages {
+		if msg.SentAt.Before(since) || t.expired(msg, now) {
+			continue
+		}
+		if status != "" && msg.Status != status {
+			continue
+		}
+		result = append(result, *msg)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].SentAt.After(result[j].SentAt) })
+	return result
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// defaultTypingDelay is how long the typing indicator shows before a message is sent
+const defaultTypingDelay = 2 * time.Second
+
+// SetTypingDelay sets how long the typing indicator shows before a message sent with
+// simulated typing goes out
+func (w *WhatsAppClient) SetTypingDelay(delay time.Duration) {
+	if delay < 0 {
+		delay = defaultTypingDelay
+	}
+	w.typingDelay = delay
+}
+
+// SendTextWithPresence shows a "typing…" indicator in the chat for the typing delay,
+// then sends the text message and returns its ID
+func (w *WhatsAppClient) SendTextWithPresence(ctx context.Context, toJID string, text string) (string, error) {
+	return w.SendTextWithOptions(ctx, toJID, text, SendOptions{SimulateTyping: true})
+}
+
+// simulateTyping sends the composing presence and waits for the typing delay. It returns a
+// function that sets the presence back to paused. Presence failures are only logged,
+// since the message can still be sent without the indicator.
+func (w *WhatsAppClient) simulateTyping(ctx context.Context, jid types.JID) (func(), error) {
+	if err := w.Client.SendChatPresence(jid, types.ChatPresenceComposing, types.ChatPresenceMediaText); err != nil {
+		w.log.Warnf("Failed to send typing indicator to %s: %v", jid, err)
+		return func() {}, nil
+	}
+
+	paused := func() {
+		if err := w.Client.SendChatPresence(jid, types.ChatPresencePaused, types.ChatPresenceMediaText); err != nil {
+			w.log.Debugf("Failed to clear typing indicator for %s: %v", jid, err)
+		}
+	}
+
+	select {
+	case <-time.After(w.typingDelay):
+		return paused, nil
+	case <-ctx.Done():
+		paused()
+		return func() {}, ctx.Err()
+	}
+}
+
+// presenceSubscriptions tracks the recipients subscribed to on the current connection;
+// WhatsApp drops subscriptions when the connection does
+type presenceSubscriptions struct {
+	subscribed map[types.JID]bool
+	mutex      sync.Mutex
+}
+
+// SetSubscribePresence controls whether the client marks itself online after connecting and
+// subscribes to the presence of each user it sends to, which makes WhatsApp deliver receipts
+// and presence updates more reliably at the cost of showing the account as online
+func (w *WhatsAppClient) SetSubscribePresence(enabled bool) {
+	if !enabled {
+		w.presence = nil
+		return
+	}
+	w.presence = &presenceSubscriptions{subscribed: make(map[types.JID]bool)}
+}
+
+// handlePresenceEvents marks the account online on each connection and forgets the
+// previous connection's subscriptions
+func (w *WhatsAppClient) handlePresenceEvents(evt interface{}) {
+	presence := w.presence
+	if presence == nil {
+		return
+	}
+
+	if _, ok := evt.(*events.Connected); !ok {
+		return
+	}
+
+	presence.mutex.Lock()
+	clear(presence.subscribed)
+	presence.mutex.Unlock()
+
+	if err := w.Client.SendPresence(types.PresenceAvailable); err != nil {
+		w.log.Warnf("Failed to mark account online: %v", err)
+	}
+}
+
+// subscribePresence subscribes to a user's presence once per connection. Groups and
+// other non-user chats are skipped. Failures are only logged, since the send succeeded.
+func (w *WhatsAppClient) subscribePresence(jid types.JID) {
+	presence := w.presence
+	if presence == nil || (jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer) {
+		return
+	}
+
+	presence.mutex.Lock()
+	if presence.subscribed[jid] {
+		presence.mutex.Unlock()
+		return
+	}
+	presence.subscribed[jid] = true
+	presence.mutex.Unlock()
+
+	if err := w.Client.SubscribePresence(jid); err != nil {
+		w.log.Warnf("Failed to subscribe to presence of %s: %v", jid, err)
+
+		// Retry on the next send
+		presence.mutex.Lock()
+		delete(presence.subscribed, jid)
+		presence.mutex.Unlock()
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SendReaction reacts to a message in the given chat with an emoji. senderJID is the
+// author of the target message; leave it empty to react to one of our own messages.
+// An empty emoji removes a previous reaction.
+func (w *WhatsAppClient) SendReaction(ctx context.Context, chatJID, messageID, senderJID, emoji string) error {
+	chat, err := w.resolveRecipient(ctx, chatJID)
+	if err != nil {
+		return err
+	}
+
+	sender := types.EmptyJID
+	if senderJID != "" {
+		sender, err = types.ParseJID(senderJID)
+		if err != nil {
+			return fmt.Errorf("invalid sender JID %s: %w", senderJID, err)
+		}
+	}
+
+	_, err = w.sendMessage(ctx, chat, w.Client.BuildReaction(chat, sender, messageID, emoji))
+	return err
+}
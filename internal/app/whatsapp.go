@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -25,34 +27,147 @@ import (
 type WhatsAppClient struct {
 	Client    *whatsmeow.Client
 	Container *sqlstore.Container
-	log       *logger.Logger
+	Events    *EventBus        // Single fan-out point for WhatsApp events
+	Outgoing  *OutgoingTracker // Delivery state of recently sent messages
+
+	ConnectionHistory *ConnectionHistory // Recent connect/disconnect transitions
+
+	MessageLog *MessageLog // Audit log of send attempts; nil when the client was built from a bare container
+	log        *logger.Logger
 
 	// Reconnection handling
-	isConnected     bool
-	reconnectMutex  sync.RWMutex
-	reconnectConfig ReconnectConfig
-	cancelReconnect context.CancelFunc
+	isConnected bool
+	connectedAt time.Time     // When the client last became connected
+	readyGrace  time.Duration // Wait after connecting before the client is considered ready
+
+	disableLinkPreviews  bool          // Disable link previews on every text message
+	messageFooter        string        // Appended after a blank line to every text message
+	typingDelay          time.Duration // How long the typing indicator shows before a message with simulated typing
+	checkGroupMembership bool          // Verify group membership before sending to a group
+	reconnectMutex       sync.RWMutex
+	reconnectConfig      ReconnectConfig
+	qrConfig             QRConfig
+	cancelReconnect      context.CancelFunc
+
+	// Reconnection exhaustion: set when all retries fail, cleared on the next connection
+	reconnectExhaustedAt time.Time
+	reconnectExhaustions int                // Times reconnection has given up since startup
+	onReconnectExhausted func(attempts int) // Called once each time reconnection gives up
+
+	// Outcome of the most recent send
+	sendMutex    sync.RWMutex
+	lastSendErr  error
+	lastSendTime time.Time
+	lastTo       string // Recipient of the most recent successful send
+
+	avatars *avatarCache // Sender profile-picture URLs; nil when disabled
+
+	// Sends in flight, drained before disconnecting on shutdown
+	inFlightMutex sync.Mutex
+	inFlight      sync.WaitGroup
+	draining      bool // Set once shutdown begins; new sends fail with ErrShuttingDown
+
+	disappearing *disappearingTimers    // Known disappearing-message timers per chat; nil disables matching
+	presence     *presenceSubscriptions // Recipients subscribed to on this connection; nil disables subscribing
+
+	// Cached directory lists; nil when caching is disabled
+	groupsCache   *cachedList[[]*types.GroupInfo]
+	contactsCache *cachedList[map[types.JID]types.ContactInfo]
+
+	// Latest QR code emitted during authentication
+	qrMutex     sync.RWMutex
+	qrCode      string
+	qrUpdatedAt time.Time
+
+	// Serializes opening the login connection between QR authentication and phone pairing
+	loginMutex sync.Mutex
 }
 
 // ReconnectConfig holds configuration for automatic reconnection
 type ReconnectConfig struct {
-	MaxRetries      int           // Maximum number of reconnection attempts
+	MaxRetries      int           // Maximum number of reconnection attempts (UnlimitedRetries retries forever)
 	InitialInterval time.Duration // Initial retry interval
 	MaxInterval     time.Duration // Maximum retry interval
 	Multiplier      float64       // Backoff multiplier
 }
 
+// UnlimitedRetries as ReconnectConfig.MaxRetries keeps reconnecting until it succeeds
+const UnlimitedRetries = 0
+
+// QRConfig holds configuration for QR code authentication
+type QRConfig struct {
+	MaxAttempts  int           // QR codes generated before giving up
+	AttemptDelay time.Duration // Wait before generating the next QR code
+	Timeout      time.Duration // How long each QR code attempt may take
+}
+
+// defaultQRConfig is used for any QRConfig field that isn't positive
+var defaultQRConfig = QRConfig{
+	MaxAttempts:  5,
+	AttemptDelay: 5 * time.Second,
+	Timeout:      60 * time.Second,
+}
+
+// withDefaults returns the config with unset fields filled in from defaultQRConfig
+func (c QRConfig) withDefaults() QRConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultQRConfig.MaxAttempts
+	}
+	if c.AttemptDelay <= 0 {
+		c.AttemptDelay = defaultQRConfig.AttemptDelay
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultQRConfig.Timeout
+	}
+	return c
+}
+
 // NewWhatsAppClient creates and initializes a new WhatsApp client
-func NewWhatsAppClient(ctx context.Context, dbDriver, dbDSN, logLevel, deviceName string, log *logger.Logger) (*WhatsAppClient, error) {
+func NewWhatsAppClient(ctx context.Context, dbDriver, dbDSN, logLevel, deviceName string, qr QRConfig, log *logger.Logger) (_ *WhatsAppClient, err error) {
 	// Create database logger
 	dbLog := waLog.Stdout("Database", logLevel, true)
 
+	// Open the database; the session store and the message audit log share the connection
+	db, err := sql.Open(dbDriver, dbDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() {
+		// Nothing else holds the connection if the client isn't returned
+		if err != nil {
+			db.Close()
+		}
+	}()
+
 	// Initialize database container
-	container, err := sqlstore.New(ctx, dbDriver, dbDSN, dbLog)
+	container := sqlstore.NewWithDB(db, dbDriver, dbLog)
+	if err := container.Upgrade(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create database container: %w", databaseError(err, dbDriver, dbDSN))
+	}
+
+	// An up-to-date schema needs no writes, so probe explicitly for a read-only store
+	if err := checkDatabaseWritable(ctx, db, dbDriver, dbDSN); err != nil {
+		return nil, err
+	}
+
+	wac, err := NewWhatsAppClientWithContainer(ctx, container, logLevel, deviceName, log)
+	if err != nil {
+		return nil, err
+	}
+
+	wac.MessageLog, err = NewMessageLog(ctx, db, dbDriver)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database container: %w", err)
+		return nil, err
 	}
 
+	wac.qrConfig = qr.withDefaults()
+
+	return wac, nil
+}
+
+// NewWhatsAppClientWithContainer creates a WhatsApp client backed by a pre-built store container.
+// This allows callers such as tests to supply an in-memory store.
+func NewWhatsAppClientWithContainer(ctx context.Context, container *sqlstore.Container, logLevel, deviceName string, log *logger.Logger) (*WhatsAppClient, error) {
 	// Get the first device from the store
 	deviceStore, err := container.GetFirstDevice(ctx)
 	if err != nil {
@@ -78,7 +193,15 @@ func NewWhatsAppClient(ctx context.Context, dbDriver, dbDSN, logLevel, deviceNam
 	wac := &WhatsAppClient{
 		Client:    client,
 		Container: container,
+		Events:    NewEventBus(),
+		Outgoing:  NewOutgoingTracker(1000),
 		log:       log,
+
+		ConnectionHistory: NewConnectionHistory(100),
+
+		checkGroupMembership: true,
+		typingDelay:          defaultTypingDelay,
+		qrConfig:             defaultQRConfig,
 		reconnectConfig: ReconnectConfig{
 			MaxRetries:      10,
 			InitialInterval: 5 * time.Second,
@@ -87,24 +210,83 @@ func NewWhatsAppClient(ctx context.Context, dbDriver, dbDSN, logLevel, deviceNam
 		},
 	}
 
-	// Add internal event handler for connection management
-	wac.Client.AddEventHandler(wac.handleConnectionEvents)
+	// Feed all client events into the event bus; connection management subscribes first
+	// so its state is updated before any other subscriber sees the event
+	wac.Client.AddEventHandler(wac.Events.Dispatch)
+	wac.Events.OnEvent(wac.handleConnectionEvents)
+	wac.Events.OnReceipt(wac.Outgoing.HandleReceipt)
+	wac.Events.OnEvent(wac.handleDisappearingEvents)
+	wac.Events.OnEvent(wac.handlePresenceEvents)
+	wac.Events.OnEvent(wac.handleDirectoryEvents)
 
 	return wac, nil
 }
 
+// SetReadyGracePeriod sets how long EnsureConnected waits after a connection is
+// established before the client is considered ready to send
+func (w *WhatsAppClient) SetReadyGracePeriod(grace time.Duration) {
+	w.reconnectMutex.Lock()
+	defer w.reconnectMutex.Unlock()
+	w.readyGrace = grace
+}
+
+// SetReconnectConfig sets the backoff used to reconnect a dropped connection.
+// A MaxRetries of 0 retries forever.
+func (w *WhatsAppClient) SetReconnectConfig(config ReconnectConfig) {
+	w.reconnectMutex.Lock()
+	defer w.reconnectMutex.Unlock()
+	w.reconnectConfig = config
+}
+
+// SetReconnectExhaustedHandler sets a function called when all reconnection attempts
+// have failed and the session stays down until it is re-authenticated or restarted
+func (w *WhatsAppClient) SetReconnectExhaustedHandler(handler func(attempts int)) {
+	w.reconnectMutex.Lock()
+	defer w.reconnectMutex.Unlock()
+	w.onReconnectExhausted = handler
+}
+
+// ReconnectExhausted returns when reconnection last gave up and whether the
+// client is still down because of it
+func (w *WhatsAppClient) ReconnectExhausted() (time.Time, bool) {
+	w.reconnectMutex.RLock()
+	defer w.reconnectMutex.RUnlock()
+	return w.reconnectExhaustedAt, !w.reconnectExhaustedAt.IsZero()
+}
+
+// SetDisableLinkPreviews disables link previews on all outgoing text messages
+func (w *WhatsAppClient) SetDisableLinkPreviews(disabled bool) {
+	w.disableLinkPreviews = disabled
+}
+
+// SetMessageFooter sets a footer appended after a blank line to every text message
+// not sent with SendOptions.OmitFooter. An empty footer disables it.
+func (w *WhatsAppClient) SetMessageFooter(footer string) {
+	w.messageFooter = footer
+}
+
+// markConnectedLocked records a transition to connected; callers must hold reconnectMutex
+func (w *WhatsAppClient) markConnectedLocked() {
+	if !w.isConnected {
+		w.connectedAt = time.Now()
+	}
+	w.isConnected = true
+	w.reconnectExhaustedAt = time.Time{}
+}
+
 // handleConnectionEvents handles connection-related events for automatic reconnection
 func (w *WhatsAppClient) handleConnectionEvents(evt interface{}) {
 	switch v := evt.(type) {
 	case *events.Connected:
 		w.reconnectMutex.Lock()
-		w.isConnected = true
+		w.markConnectedLocked()
 		// Cancel any ongoing reconnection attempts since we're now connected
 		if w.cancelReconnect != nil {
 			w.cancelReconnect()
 			w.cancelReconnect = nil
 		}
 		w.reconnectMutex.Unlock()
+		w.ConnectionHistory.Record(StateConnected, "")
 		w.log.Info("WhatsApp client connected")
 
 	case *events.Disconnected:
@@ -113,6 +295,7 @@ func (w *WhatsAppClient) handleConnectionEvents(evt interface{}) {
 		shouldReconnect := w.cancelReconnect == nil // Only start reconnection if not already in progress
 		w.reconnectMutex.Unlock()
 
+		w.ConnectionHistory.Record(StateDisconnected, "connection closed")
 		w.log.Warn("WhatsApp client disconnected")
 
 		// Start reconnection process if not already in progress and not explicitly disconnected
@@ -121,7 +304,20 @@ func (w *WhatsAppClient) handleConnectionEvents(evt interface{}) {
 		}
 
 	case *events.StreamError:
+		w.ConnectionHistory.Record(StateDisconnected, "stream error: "+v.Code)
 		w.log.Errorf("WhatsApp stream error: %v", v)
+
+	case *events.LoggedOut:
+		w.ConnectionHistory.Record(StateLoggedOut, v.Reason.String())
+
+	case *events.StreamReplaced:
+		w.ConnectionHistory.Record(StateDisconnected, "stream replaced by another connection")
+
+	case *events.ConnectFailure:
+		w.ConnectionHistory.Record(StateDisconnected, "connect failure: "+v.Reason.String())
+
+	case *events.TemporaryBan:
+		w.ConnectionHistory.Record(StateDisconnected, "temporary ban: "+v.String())
 	}
 }
 
@@ -145,9 +341,15 @@ func (w *WhatsAppClient) startReconnection() {
 		w.reconnectMutex.Unlock()
 	}()
 
-	interval := w.reconnectConfig.InitialInterval
+	w.reconnectMutex.RLock()
+	reconnectConfig := w.reconnectConfig
+	w.reconnectMutex.RUnlock()
 
-	for attempt := 1; attempt <= w.reconnectConfig.MaxRetries; attempt++ {
+	interval := reconnectConfig.InitialInterval
+
+	unlimited := reconnectConfig.MaxRetries == UnlimitedRetries
+
+	for attempt := 1; unlimited || attempt <= reconnectConfig.MaxRetries; attempt++ {
 		select {
 		case <-ctx.Done():
 			w.log.Info("Reconnection cancelled")
@@ -163,13 +365,17 @@ func (w *WhatsAppClient) startReconnection() {
 				return
 			}
 
-			w.log.Infof("Reconnection attempt %d/%d", attempt, w.reconnectConfig.MaxRetries)
+			if unlimited {
+				w.log.Infof("Reconnection attempt %d", attempt)
+			} else {
+				w.log.Infof("Reconnection attempt %d/%d", attempt, reconnectConfig.MaxRetries)
+			}
 
 			// Check if client is already connected at the protocol level
 			if w.Client.IsConnected() {
 				w.log.Info("Client already connected at protocol level")
 				w.reconnectMutex.Lock()
-				w.isConnected = true
+				w.markConnectedLocked()
 				w.reconnectMutex.Unlock()
 				return
 			}
@@ -178,9 +384,9 @@ func (w *WhatsAppClient) startReconnection() {
 				w.log.Errorf("Reconnection attempt %d failed: %v", attempt, err)
 
 				// Calculate next interval with exponential backoff
-				interval = time.Duration(float64(interval) * w.reconnectConfig.Multiplier)
-				if interval > w.reconnectConfig.MaxInterval {
-					interval = w.reconnectConfig.MaxInterval
+				interval = time.Duration(float64(interval) * reconnectConfig.Multiplier)
+				if interval > reconnectConfig.MaxInterval {
+					interval = reconnectConfig.MaxInterval
 				}
 
 				continue
@@ -191,7 +397,23 @@ func (w *WhatsAppClient) startReconnection() {
 		}
 	}
 
-	w.log.Error("All reconnection attempts failed", nil)
+	w.reconnectExhausted(reconnectConfig.MaxRetries)
+}
+
+// reconnectExhausted records that reconnection gave up and notifies the exhaustion handler
+func (w *WhatsAppClient) reconnectExhausted(attempts int) {
+	w.reconnectMutex.Lock()
+	w.reconnectExhaustedAt = time.Now()
+	w.reconnectExhaustions++
+	handler := w.onReconnectExhausted
+	w.reconnectMutex.Unlock()
+
+	w.ConnectionHistory.Record(StateDisconnected, fmt.Sprintf("all %d reconnection attempts failed", attempts))
+	w.log.Errorf("All %d reconnection attempts failed; the WhatsApp session stays down until it is re-authenticated or the service is restarted", attempts)
+
+	if handler != nil {
+		handler(attempts)
+	}
 }
 
 // Connect connects the WhatsApp client
@@ -220,7 +442,7 @@ func (w *WhatsAppClient) Connect(ctx context.Context) error {
 	}
 
 	w.reconnectMutex.Lock()
-	w.isConnected = true
+	w.markConnectedLocked()
 	w.reconnectMutex.Unlock()
 
 	w.log.Info("Successfully connected to WhatsApp")
@@ -230,11 +452,7 @@ func (w *WhatsAppClient) Connect(ctx context.Context) error {
 
 // authenticateWithQR handles QR code authentication with automatic retry
 func (w *WhatsAppClient) authenticateWithQR(ctx context.Context) {
-	const (
-		maxAttempts  = 5
-		qrTimeout    = 60 * time.Second
-		attemptDelay = 5 * time.Second
-	)
+	maxAttempts := w.qrConfig.MaxAttempts
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		select {
@@ -245,16 +463,16 @@ func (w *WhatsAppClient) authenticateWithQR(ctx context.Context) {
 
 		if attempt > 1 {
 			w.log.Infof("Generating new QR code (attempt %d/%d)...", attempt, maxAttempts)
-			time.Sleep(attemptDelay)
+			time.Sleep(w.qrConfig.AttemptDelay)
 		}
 
 		// Attempt single QR authentication
-		if success, cancelled := w.attemptQRAuth(ctx, qrTimeout); cancelled {
+		if success, cancelled := w.attemptQRAuth(ctx, w.qrConfig.Timeout); cancelled {
 			w.log.Info("QR authentication cancelled")
 			return
 		} else if success {
 			w.reconnectMutex.Lock()
-			w.isConnected = true
+			w.markConnectedLocked()
 			w.reconnectMutex.Unlock()
 
 			w.log.Info("WhatsApp authentication successful")
@@ -275,18 +493,31 @@ func (w *WhatsAppClient) attemptQRAuth(ctx context.Context, timeout time.Duratio
 	qrCtx, qrCancel := context.WithTimeout(ctx, timeout)
 	defer qrCancel()
 
+	w.loginMutex.Lock()
 	qrChan, err := w.Client.GetQRChannel(qrCtx)
-	if err != nil {
-		w.log.Errorf("Failed to get QR channel: %v", err)
-		return false, false
-	}
-
-	if !w.Client.IsConnected() {
+	if err == nil && !w.Client.IsConnected() {
 		if err := w.Client.Connect(); err != nil {
+			w.loginMutex.Unlock()
 			w.log.Errorf("Failed to connect client: %v", err)
 			return false, false
 		}
 	}
+	w.loginMutex.Unlock()
+
+	if errors.Is(err, whatsmeow.ErrQRAlreadyConnected) {
+		// Phone pairing opened the login connection; let it run its course
+		w.log.Info("Login connection is in use for phone pairing, skipping this QR code")
+		select {
+		case <-ctx.Done():
+			return false, true
+		case <-qrCtx.Done():
+			return false, false
+		}
+	}
+	if err != nil {
+		w.log.Errorf("Failed to get QR channel: %v", err)
+		return false, false
+	}
 
 	return w.handleQREvents(ctx, qrCtx, qrChan)
 }
@@ -298,9 +529,11 @@ func (w *WhatsAppClient) handleQREvents(parentCtx, qrCtx context.Context, qrChan
 	for {
 		select {
 		case <-parentCtx.Done():
+			w.setQRCode("")
 			return false, true
 
 		case <-qrCtx.Done():
+			w.setQRCode("")
 			if qrDisplayed {
 				w.log.Warn("QR code timed out without being scanned")
 			}
@@ -309,19 +542,23 @@ func (w *WhatsAppClient) handleQREvents(parentCtx, qrCtx context.Context, qrChan
 		case evt, ok := <-qrChan:
 			if !ok {
 				// Channel closed - check cancellation
+				w.setQRCode("")
 				return false, parentCtx.Err() != nil
 			}
 
 			switch evt.Event {
 			case "code":
 				qrDisplayed = true
+				w.setQRCode(evt.Code)
 				w.displayQRCode(evt.Code)
 
 			case "success":
+				w.setQRCode("")
 				w.log.Info("QR code scanned successfully!")
 				return true, false
 
 			case "timeout":
+				w.setQRCode("")
 				w.log.Warn("QR code expired, generating new one...")
 				return false, false
 
@@ -332,6 +569,98 @@ func (w *WhatsAppClient) handleQREvents(parentCtx, qrCtx context.Context, qrChan
 	}
 }
 
+// setQRCode stores the latest QR code, or clears it when code is empty
+func (w *WhatsAppClient) setQRCode(code string) {
+	w.qrMutex.Lock()
+	defer w.qrMutex.Unlock()
+
+	w.qrCode = code
+	w.qrUpdatedAt = time.Now()
+}
+
+// CurrentQRCode returns the QR code currently awaiting a scan and when it was generated.
+// ok is false when the client is already paired or no QR code is active.
+func (w *WhatsAppClient) CurrentQRCode() (code string, generatedAt time.Time, ok bool) {
+	if w.Client.Store.ID != nil {
+		return "", time.Time{}, false
+	}
+
+	w.qrMutex.RLock()
+	defer w.qrMutex.RUnlock()
+
+	if w.qrCode == "" {
+		return "", time.Time{}, false
+	}
+	return w.qrCode, w.qrUpdatedAt, true
+}
+
+// ErrAlreadyPaired is returned when pairing is attempted while a session already exists
+var ErrAlreadyPaired = errors.New("client is already paired")
+
+// PairWithPhone starts phone-number pairing as an alternative to scanning a QR code
+// and returns the 8-character linking code to enter in WhatsApp on the phone
+func (w *WhatsAppClient) PairWithPhone(ctx context.Context, phoneNumber string) (string, error) {
+	if w.Client.Store.ID != nil {
+		return "", ErrAlreadyPaired
+	}
+
+	// Pairing needs the login websocket past its handshake, which WhatsApp signals
+	// by sending the QR codes. Listen before connecting so the event can't be missed.
+	loginReady := make(chan struct{}, 1)
+	markReady := func() {
+		select {
+		case loginReady <- struct{}{}:
+		default:
+		}
+	}
+	handlerID := w.Client.AddEventHandler(func(evt interface{}) {
+		if _, ok := evt.(*events.QR); ok {
+			markReady()
+		}
+	})
+	defer w.Client.RemoveEventHandler(handlerID)
+
+	// QR authentication normally keeps the login connection open; open it if it doesn't
+	w.loginMutex.Lock()
+	if !w.Client.IsConnected() {
+		if err := w.Client.Connect(); err != nil {
+			w.loginMutex.Unlock()
+			return "", fmt.Errorf("failed to connect client: %w", err)
+		}
+	} else if _, _, ok := w.CurrentQRCode(); ok {
+		// The QR flow's connection has already received its codes
+		markReady()
+	}
+	w.loginMutex.Unlock()
+
+	waitCtx, cancel := context.WithTimeout(ctx, pairingConnectTimeout)
+	defer cancel()
+	select {
+	case <-loginReady:
+	case <-waitCtx.Done():
+		return "", fmt.Errorf("login connection not ready: %w", waitCtx.Err())
+	}
+
+	code, err := w.Client.PairPhone(ctx, phoneNumber, true, whatsmeow.PairClientChrome, "Chrome (Linux)")
+	if err != nil {
+		return "", fmt.Errorf("failed to request pairing code: %w", err)
+	}
+
+	w.log.Infof("Pairing code generated for %s", maskPhoneNumber(phoneNumber))
+	return code, nil
+}
+
+// pairingConnectTimeout bounds the wait for the login connection before requesting a pairing code
+const pairingConnectTimeout = 30 * time.Second
+
+// maskPhoneNumber hides all but the last four digits of a phone number for logging
+func maskPhoneNumber(phone string) string {
+	if len(phone) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
+
 // displayQRCode renders the QR code in the terminal
 func (w *WhatsAppClient) displayQRCode(code string) {
 	fmt.Println("\n" + strings.Repeat("=", 64))
@@ -346,7 +675,7 @@ func (w *WhatsAppClient) displayQRCode(code string) {
 	})
 
 	fmt.Println(strings.Repeat("=", 64))
-	fmt.Println("⏰ You have 60 seconds to scan the QR code")
+	fmt.Printf("⏰ You have %s to scan the QR code\n", w.qrConfig.Timeout)
 	fmt.Println("📱 Open WhatsApp > Settings > Linked Devices > Link a Device")
 	fmt.Println(strings.Repeat("=", 64) + "\n")
 }
@@ -364,36 +693,241 @@ func (w *WhatsAppClient) Disconnect() {
 
 	w.Client.Disconnect()
 	w.isConnected = false
+	w.ConnectionHistory.Record(StateDisconnected, "client shutdown")
 	w.log.Info("Disconnected from WhatsApp")
 }
 
-// AddEventHandler adds an event handler to the client
-func (w *WhatsAppClient) AddEventHandler(handler func(interface{})) {
-	w.Client.AddEventHandler(handler)
+// AddEventHandler subscribes a handler to all events and returns a function that unsubscribes
+func (w *WhatsAppClient) AddEventHandler(handler func(interface{})) func() {
+	return w.Events.OnEvent(handler)
+}
+
+// forwardedManyTimesScore is the lowest forwarding score WhatsApp labels as "Forwarded many times"
+const forwardedManyTimesScore = 5
+
+// SendOptions holds optional settings for outgoing text messages
+type SendOptions struct {
+	Forwarded          bool     // Mark the message as "Forwarded many times"
+	DisableLinkPreview bool     // Send as an extended text message with previews explicitly disabled
+	Mentions           []string // JIDs of users @mentioned in the text
+
+	// Reply to a prior message; both must be set
+	QuotedMessageID string // ID of the message being replied to
+	QuotedJID       string // JID of the quoted message's sender
+
+	// Disappearing-message timer in seconds (0 for a regular message); nil matches the chat's timer
+	DisappearingTimer *uint32
+
+	SimulateTyping bool // Show a "typing…" indicator for the typing delay before sending
+	OmitFooter     bool // Send without the configured message footer
+}
+
+// SendText sends a text message to the specified JID and returns the message ID
+func (w *WhatsAppClient) SendText(ctx context.Context, toJID string, text string) (string, error) {
+	return w.SendTextWithOptions(ctx, toJID, text, SendOptions{})
+}
+
+// SendTextWithOptions sends a text message to the specified JID using the given options
+// and returns the message ID
+func (w *WhatsAppClient) SendTextWithOptions(ctx context.Context, toJID string, text string, opts SendOptions) (string, error) {
+	jid, err := w.resolveRecipient(ctx, toJID)
+	if err != nil {
+		return "", err
+	}
+
+	// The global setting overrides any per-request preference
+	if w.disableLinkPreviews {
+		opts.DisableLinkPreview = true
+	}
+
+	if w.messageFooter != "" && !opts.OmitFooter {
+		text += "\n\n" + w.messageFooter
+	}
+
+	timer := w.resolveDisappearingTimer(jid, opts.DisappearingTimer)
+	opts.DisappearingTimer = &timer
+
+	if opts.SimulateTyping {
+		paused, err := w.simulateTyping(ctx, jid)
+		if err != nil {
+			return "", err
+		}
+		defer paused()
+	}
+
+	resp, err := w.sendMessage(ctx, jid, buildTextMessage(text, opts))
+	return resp.ID, err
+}
+
+// SendGroupText sends a text message to a group, @mentioning the given user JIDs.
+// The text should contain an @number token for each mention so it renders as a tag.
+func (w *WhatsAppClient) SendGroupText(ctx context.Context, groupJID string, text string, mentions []string, opts SendOptions) (string, error) {
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return "", fmt.Errorf("invalid JID %s: %w", groupJID, err)
+	}
+	if jid.Server != types.GroupServer {
+		return "", fmt.Errorf("%s is not a group JID", groupJID)
+	}
+
+	opts.Mentions = mentions
+	return w.SendTextWithOptions(ctx, groupJID, text, opts)
+}
+
+// ErrNotGroupMember is returned when sending to a group the account isn't a member of
+var ErrNotGroupMember = errors.New("not a member of group")
+
+// SetCheckGroupMembership controls whether sends to groups first verify membership
+func (w *WhatsAppClient) SetCheckGroupMembership(enabled bool) {
+	w.checkGroupMembership = enabled
 }
 
-// SendText sends a text message to the specified JID
-func (w *WhatsAppClient) SendText(ctx context.Context, toJID string, text string) error {
+// resolveRecipient parses a JID and, for groups, verifies the account is a member
+func (w *WhatsAppClient) resolveRecipient(ctx context.Context, toJID string) (types.JID, error) {
 	jid, err := types.ParseJID(toJID)
 	if err != nil {
-		return fmt.Errorf("invalid JID %s: %w", toJID, err)
+		return types.JID{}, fmt.Errorf("invalid JID %s: %w", toJID, err)
 	}
 
-	msg := &waE2E.Message{
-		Conversation: proto.String(text),
+	if jid.Server != types.GroupServer || !w.checkGroupMembership {
+		return jid, nil
 	}
 
-	_, err = w.Client.SendMessage(ctx, jid, msg)
+	groups, err := w.GetJoinedGroups(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+		return types.JID{}, err
+	}
+	for _, group := range groups {
+		if group.JID == jid {
+			return jid, nil
+		}
 	}
 
-	w.log.Infof("Message sent to %s", toJID)
-	return nil
+	return types.JID{}, fmt.Errorf("%w %s", ErrNotGroupMember, toJID)
+}
+
+// sendMessage sends a prepared message to the specified JID and tracks its delivery
+func (w *WhatsAppClient) sendMessage(ctx context.Context, jid types.JID, msg *waE2E.Message) (whatsmeow.SendResponse, error) {
+	if err := w.beginSend(); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	defer w.endSend()
+
+	resp, err := w.Client.SendMessage(ctx, jid, msg)
+	w.recordSendResult(err)
+
+	if w.MessageLog != nil {
+		// Record the attempt even if the request was cancelled mid-send
+		if logErr := w.MessageLog.Record(context.WithoutCancel(ctx), resp.ID, jid.String(), messagePreview(msg), err); logErr != nil {
+			w.log.Errorf("Failed to write message audit log: %v", logErr)
+		}
+	}
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	w.Outgoing.Record(resp.ID, jid.String(), resp.Timestamp)
+	w.setLastRecipient(jid.String())
+	w.subscribePresence(jid)
+
+	w.log.Infof("Message sent to %s", jid.String())
+	return resp, nil
+}
+
+// recordSendResult remembers the outcome of the most recent send
+func (w *WhatsAppClient) recordSendResult(err error) {
+	w.sendMutex.Lock()
+	defer w.sendMutex.Unlock()
+
+	w.lastSendErr = err
+	w.lastSendTime = time.Now()
+}
+
+// LastSendResult returns when the most recent send happened and its error,
+// which is nil if it succeeded or nothing has been sent yet
+func (w *WhatsAppClient) LastSendResult() (time.Time, error) {
+	w.sendMutex.RLock()
+	defer w.sendMutex.RUnlock()
+
+	return w.lastSendTime, w.lastSendErr
+}
+
+// setLastRecipient remembers the recipient of the most recent successful send
+func (w *WhatsAppClient) setLastRecipient(jid string) {
+	w.sendMutex.Lock()
+	defer w.sendMutex.Unlock()
+
+	w.lastTo = jid
+}
+
+// LastRecipient returns the recipient of the most recent successful send, if any
+func (w *WhatsAppClient) LastRecipient() (string, bool) {
+	w.sendMutex.RLock()
+	defer w.sendMutex.RUnlock()
+
+	return w.lastTo, w.lastTo != ""
+}
+
+// buildTextMessage builds a plain conversation message, or an extended text
+// message when options require context info or preview settings
+func buildTextMessage(text string, opts SendOptions) *waE2E.Message {
+	quoted := opts.QuotedMessageID != "" && opts.QuotedJID != ""
+	disappearing := opts.DisappearingTimer != nil && *opts.DisappearingTimer > 0
+
+	if !opts.Forwarded && !opts.DisableLinkPreview && len(opts.Mentions) == 0 && !quoted && !disappearing {
+		return &waE2E.Message{
+			Conversation: proto.String(text),
+		}
+	}
+
+	extended := &waE2E.ExtendedTextMessage{
+		Text: proto.String(text),
+	}
+
+	if opts.Forwarded || len(opts.Mentions) > 0 || quoted {
+		extended.ContextInfo = &waE2E.ContextInfo{}
+	}
+
+	if opts.Forwarded {
+		extended.ContextInfo.IsForwarded = proto.Bool(true)
+		extended.ContextInfo.ForwardingScore = proto.Uint32(forwardedManyTimesScore)
+	}
+
+	if len(opts.Mentions) > 0 {
+		extended.ContextInfo.MentionedJID = opts.Mentions
+	}
+
+	if quoted {
+		// The original content isn't known here; WhatsApp resolves it from the stanza ID
+		extended.ContextInfo.StanzaID = proto.String(opts.QuotedMessageID)
+		extended.ContextInfo.Participant = proto.String(opts.QuotedJID)
+		extended.ContextInfo.QuotedMessage = &waE2E.Message{Conversation: proto.String("")}
+	}
+
+	if disappearing {
+		extended.ContextInfo = disappearingContextInfo(extended.ContextInfo, *opts.DisappearingTimer)
+	}
+
+	if opts.DisableLinkPreview {
+		extended.PreviewType = waE2E.ExtendedTextMessage_NONE.Enum()
+	}
+
+	return &waE2E.Message{
+		ExtendedTextMessage: extended,
+	}
 }
 
-// GetContacts retrieves all contacts from the store
+// GetContacts retrieves all contacts, from the directory cache when enabled.
+// The returned map is shared with the cache and must not be modified.
 func (w *WhatsAppClient) GetContacts(ctx context.Context) (map[types.JID]types.ContactInfo, error) {
+	if w.contactsCache != nil {
+		return w.contactsCache.get(ctx)
+	}
+	return w.fetchContacts(ctx)
+}
+
+// fetchContacts retrieves all contacts from the store
+func (w *WhatsAppClient) fetchContacts(ctx context.Context) (map[types.JID]types.ContactInfo, error) {
 	contacts, err := w.Client.Store.Contacts.GetAllContacts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contacts: %w", err)
@@ -401,8 +935,77 @@ func (w *WhatsAppClient) GetContacts(ctx context.Context) (map[types.JID]types.C
 	return contacts, nil
 }
 
-// GetJoinedGroups retrieves all groups the account is a member of
+// ErrContactNotFound is returned when a contact isn't in the local store
+var ErrContactNotFound = errors.New("contact not found")
+
+// GetContact retrieves a single contact from the store
+func (w *WhatsAppClient) GetContact(ctx context.Context, jid string) (types.ContactInfo, error) {
+	parsed, err := types.ParseJID(jid)
+	if err != nil {
+		return types.ContactInfo{}, fmt.Errorf("invalid JID %s: %w", jid, err)
+	}
+
+	contact, err := w.Client.Store.Contacts.GetContact(ctx, parsed)
+	if err != nil {
+		return types.ContactInfo{}, fmt.Errorf("failed to get contact: %w", err)
+	}
+	if !contact.Found {
+		return types.ContactInfo{}, fmt.Errorf("%w: %s", ErrContactNotFound, jid)
+	}
+	return contact, nil
+}
+
+// ErrRateLimited is returned when WhatsApp rejects a query for exceeding its rate limit
+var ErrRateLimited = errors.New("rate limited by WhatsApp")
+
+// NumberStatus is the WhatsApp registration status of a phone number
+type NumberStatus struct {
+	Registered bool
+	JID        string // Resolved JID, only set when registered
+}
+
+// IsOnWhatsApp checks which phone numbers (international format, digits only)
+// are registered on WhatsApp, keyed by the number as given
+func (w *WhatsAppClient) IsOnWhatsApp(ctx context.Context, phoneNumbers []string) (map[string]NumberStatus, error) {
+	queries := make([]string, len(phoneNumbers))
+	for i, number := range phoneNumbers {
+		queries[i] = "+" + strings.TrimPrefix(number, "+")
+	}
+
+	responses, err := w.Client.IsOnWhatsApp(queries)
+	if err != nil {
+		if errors.Is(err, whatsmeow.ErrIQRateOverLimit) {
+			return nil, fmt.Errorf("%w: %v", ErrRateLimited, err)
+		}
+		return nil, fmt.Errorf("failed to check numbers: %w", err)
+	}
+
+	statuses := make(map[string]NumberStatus, len(phoneNumbers))
+	for _, number := range phoneNumbers {
+		statuses[number] = NumberStatus{}
+	}
+	for _, resp := range responses {
+		number := strings.TrimPrefix(resp.Query, "+")
+		status := NumberStatus{Registered: resp.IsIn}
+		if resp.IsIn {
+			status.JID = resp.JID.String()
+		}
+		statuses[number] = status
+	}
+	return statuses, nil
+}
+
+// GetJoinedGroups retrieves all groups the account is a member of, from the directory
+// cache when enabled. The returned groups are shared with the cache and must not be modified.
 func (w *WhatsAppClient) GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, error) {
+	if w.groupsCache != nil {
+		return w.groupsCache.get(ctx)
+	}
+	return w.fetchJoinedGroups(ctx)
+}
+
+// fetchJoinedGroups retrieves all groups the account is a member of from WhatsApp
+func (w *WhatsAppClient) fetchJoinedGroups(ctx context.Context) ([]*types.GroupInfo, error) {
 	groups, err := w.Client.GetJoinedGroups(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get joined groups: %w", err)
@@ -410,6 +1013,27 @@ func (w *WhatsAppClient) GetJoinedGroups(ctx context.Context) ([]*types.GroupInf
 	return groups, nil
 }
 
+// GetGroupMembers retrieves the participants of a group, returning ErrNotGroupMember
+// if the account isn't a member of it (or the group doesn't exist)
+func (w *WhatsAppClient) GetGroupMembers(ctx context.Context, groupJID string) ([]types.GroupParticipant, error) {
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JID %s: %w", groupJID, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info, err := w.Client.GetGroupInfo(jid)
+	if errors.Is(err, whatsmeow.ErrNotInGroup) || errors.Is(err, whatsmeow.ErrGroupNotFound) {
+		return nil, fmt.Errorf("%w %s", ErrNotGroupMember, groupJID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group info: %w", err)
+	}
+	return info.Participants, nil
+}
+
 // IsConnected checks if the client is connected
 func (w *WhatsAppClient) IsConnected() bool {
 	w.reconnectMutex.RLock()
@@ -420,14 +1044,39 @@ func (w *WhatsAppClient) IsConnected() bool {
 	return w.isConnected && w.Client.IsConnected() && hasValidSession
 }
 
-// EnsureConnected ensures the client is connected, attempting to reconnect if necessary
+// EnsureConnected ensures the client is connected, attempting to reconnect if necessary.
+// It also waits out the ready grace period after a fresh connection.
 func (w *WhatsAppClient) EnsureConnected(ctx context.Context) error {
-	if w.IsConnected() {
+	if !w.IsConnected() {
+		w.log.Info("Client not connected, attempting to connect...")
+		if err := w.Connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	return w.waitUntilReady(ctx)
+}
+
+// waitUntilReady blocks until the ready grace period since the last connection has passed
+func (w *WhatsAppClient) waitUntilReady(ctx context.Context) error {
+	w.reconnectMutex.RLock()
+	remaining := time.Until(w.connectedAt.Add(w.readyGrace))
+	w.reconnectMutex.RUnlock()
+
+	if remaining <= 0 {
 		return nil
 	}
 
-	w.log.Info("Client not connected, attempting to connect...")
-	return w.Connect(ctx)
+	w.log.Debugf("Waiting %s for connection to become ready", remaining)
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // GetConnectionStatus returns detailed connection status information
@@ -450,26 +1099,42 @@ func (w *WhatsAppClient) GetConnectionStatus() map[string]interface{} {
 			}
 			return "none"
 		}(),
-		"reconnection_active": w.cancelReconnect != nil,
+		"reconnection_active":   w.cancelReconnect != nil,
+		"reconnect_exhausted":   !w.reconnectExhaustedAt.IsZero(),
+		"reconnect_exhaustions": w.reconnectExhaustions,
 	}
 }
 
-// DefaultEventHandler is a default event handler that logs received messages
-func DefaultEventHandler(log *logger.Logger) func(interface{}) {
-	return func(evt interface{}) {
-		switch v := evt.(type) {
-		case *events.Message:
+// RegisterDefaultEventHandlers subscribes handlers that log received events
+// and returns a function that unsubscribes them all
+func RegisterDefaultEventHandlers(w *WhatsAppClient, log *logger.Logger) func() {
+	unsubscribers := []func(){
+		w.Events.OnMessage(func(v *events.Message) {
 			log.Infof("Received message from %s: %s", v.Info.Sender.String(), v.Message.GetConversation())
-		case *events.Receipt:
+		}),
+		w.Events.OnReceipt(func(v *events.Receipt) {
 			log.Debugf("Received receipt for message %s", v.MessageIDs)
-		case *events.Presence:
-			log.Debugf("Presence update from %s: unavailable=%v", v.From.String(), v.Unavailable)
-		case *events.HistorySync:
-			log.Debugf("Received history sync")
-		case *events.Connected:
-			log.Info("Client connected")
-		case *events.Disconnected:
-			log.Info("Client disconnected")
+		}),
+		w.Events.OnConnection(func(connected bool) {
+			if connected {
+				log.Info("Client connected")
+			} else {
+				log.Info("Client disconnected")
+			}
+		}),
+		w.Events.OnEvent(func(evt interface{}) {
+			switch v := evt.(type) {
+			case *events.Presence:
+				log.Debugf("Presence update from %s: unavailable=%v", v.From.String(), v.Unavailable)
+			case *events.HistorySync:
+				log.Debugf("Received history sync")
+			}
+		}),
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribers {
+			unsubscribe()
 		}
 	}
 }
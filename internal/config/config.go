@@ -2,128 +2,630 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
 // Config holds the application configuration
 type Config struct {
 	// Server configuration
-	Server ServerConfig
+	Server ServerConfig `json:"server"`
 
 	// Database configuration
-	Database DatabaseConfig
+	Database DatabaseConfig `json:"database"`
 
 	// WhatsApp configuration
-	WhatsApp WhatsAppConfig
+	WhatsApp WhatsAppConfig `json:"whatsapp"`
 
 	// Logging configuration
-	Log LogConfig
+	Log LogConfig `json:"log"`
 
 	// Security configuration
-	Security SecurityConfig
+	Security SecurityConfig `json:"security"`
+
+	// Rate limiting configuration
+	RateLimit RateLimitConfig `json:"rate_limit"`
 
 	// Gitea configuration
-	Gitea GiteaConfig
+	Gitea GiteaConfig `json:"gitea"`
 
 	// GitHub configuration
-	GitHub GitHubConfig
+	GitHub GitHubConfig `json:"github"`
+
+	// Bitbucket configuration
+	Bitbucket BitbucketConfig `json:"bitbucket"`
+
+	// Jenkins configuration
+	Jenkins JenkinsConfig `json:"jenkins"`
+
+	// Alertmanager configuration
+	Alertmanager AlertmanagerConfig `json:"alertmanager"`
+
+	// Custom webhook configuration
+	Custom CustomWebhookConfig `json:"custom"`
+
+	// Webhook routing configuration
+	Routing RoutingConfig `json:"routing"`
+
+	// Alert notification configuration
+	Alerts AlertConfig `json:"alerts"`
+
+	// Receipt confirmation link configuration
+	Confirm ConfirmConfig `json:"confirm"`
+
+	// Incoming event forwarding configuration
+	Forward ForwardConfig `json:"forward"`
 }
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
-	Host            string
-	Port            int
-	ReadTimeout     time.Duration
-	WriteTimeout    time.Duration
-	ShutdownTimeout time.Duration
+	Host            string        `json:"host"`
+	Port            int           `json:"port"`
+	ReadTimeout     time.Duration `json:"read_timeout"`
+	WriteTimeout    time.Duration `json:"write_timeout"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+	DebugMode       bool          `json:"debug_mode"`       // Allow ?debug=true on any request, not only authenticated ones
+	JSONBufferSize  int           `json:"json_buffer_size"` // Buffer JSON responses up to this many bytes before sending the status
+	JSONNotFound    bool          `json:"json_not_found"`   // Return JSON 404 errors for unknown routes instead of the net/http default
+
+	SendSuccessStatus int           `json:"send_success_status"` // HTTP status returned by /send endpoints on success (200 or 202)
+	DegradedQueueAge  time.Duration `json:"degraded_queue_age"`  // Report degraded health once a queued send has waited this long
+	HealthProbeJID    string        `json:"health_probe_jid"`    // JID looked up by /readyz to verify the connection end to end
+	HealthProbeTTL    time.Duration `json:"health_probe_ttl"`    // How long a /readyz probe result is cached
 }
 
 // DatabaseConfig holds database-specific configuration
 type DatabaseConfig struct {
-	Driver string
-	DSN    string
+	Driver string `json:"driver"`
+	DSN    string `json:"dsn"`
 }
 
 // WhatsAppConfig holds WhatsApp-specific configuration
 type WhatsAppConfig struct {
-	LogLevel   string
-	DeviceName string // Custom device name that appears in WhatsApp linked devices
+	LogLevel   string `json:"log_level"`
+	DeviceName string `json:"device_name"` // Custom device name that appears in WhatsApp linked devices
+
+	StripJIDDeviceSuffix bool          `json:"strip_jid_device_suffix"` // Normalize user.agent:device@server JIDs to user@server
+	RecipientCooldown    time.Duration `json:"recipient_cooldown"`      // Minimum interval between messages to the same recipient (0 disables)
+	MarkForwarded        bool          `json:"mark_forwarded"`          // Mark outgoing messages as "Forwarded many times" by default
+	ReadyGracePeriod     time.Duration `json:"ready_grace_period"`      // Wait after connecting before sends are accepted
+	MaxMediaSize         int           `json:"max_media_size"`          // Maximum size of uploaded media in bytes
+	DisableLinkPreviews  bool          `json:"disable_link_previews"`   // Disable link previews on every text message
+	MessageFooter        string        `json:"message_footer"`          // Appended after a blank line to every text message (empty disables)
+	CheckGroupMembership bool          `json:"check_group_membership"`  // Verify group membership before sending to a group
+	SenderAvatarTTL      time.Duration `json:"sender_avatar_ttl"`       // Cache TTL for sender profile-picture URLs in forwarded events (0 disables)
+	MatchDisappearing    bool          `json:"match_disappearing"`      // Send with the chat's disappearing-message timer unless a request sets one
+	TypingDelay          time.Duration `json:"typing_delay"`            // How long the typing indicator shows for requests with simulate_typing
+	SubscribePresence    bool          `json:"subscribe_presence"`      // Mark the account online and subscribe to recipients' presence for reliable receipts
+	DirectoryCacheTTL    time.Duration `json:"directory_cache_ttl"`     // How long joined-groups and contacts lists are cached (0 disables)
+	DirectoryCacheMax    int           `json:"directory_cache_max"`     // Lists longer than this aren't cached (0 caches any size)
+	ReceiptTTL           time.Duration `json:"receipt_ttl"`             // How long delivery receipts of sent messages are tracked (0 keeps the last 1000)
+	BulkConcurrency      int           `json:"bulk_concurrency"`        // Messages /send/bulk sends at once
+
+	SendConcurrency       int `json:"send_concurrency"`         // Sends in flight at once; further sends queue by priority (0 is unlimited)
+	LowPriorityQueueLimit int `json:"low_priority_queue_limit"` // Queued sends at which low-priority sends are rejected (0 disables)
+
+	Reconnect         ReconnectConfig `json:"reconnect"`           // Backoff for reconnecting a dropped connection
+	ReconnectAlertJID string          `json:"reconnect_alert_jid"` // Recipient alerted when reconnection gives up (empty disables)
+	CheckRecipients   bool            `json:"check_recipients"`    // Check configured recipients are registered on WhatsApp once connected
+
+	ConnectionHistorySize int `json:"connection_history_size"` // Number of connection state transitions kept for /admin/connection-history
+
+	QRMaxAttempts  int           `json:"qr_max_attempts"`  // QR codes generated before authentication gives up
+	QRAttemptDelay time.Duration `json:"qr_attempt_delay"` // Wait before generating the next QR code
+	QRTimeout      time.Duration `json:"qr_timeout"`       // How long each QR code may take to be scanned
+}
+
+// ReconnectConfig holds the backoff for reconnecting a dropped WhatsApp connection
+type ReconnectConfig struct {
+	MaxRetries      int           `json:"max_retries"`      // Attempts before giving up (0 retries forever)
+	InitialInterval time.Duration `json:"initial_interval"` // Wait before the first attempt
+	MaxInterval     time.Duration `json:"max_interval"`     // Cap on the wait between attempts
+	Multiplier      float64       `json:"multiplier"`       // Growth of the wait after each failed attempt
 }
 
 // LogConfig holds logging configuration
 type LogConfig struct {
-	Level   string
-	Format  string // "json" or "text"
-	LogFile string // Path to log file (e.g., "./log/whatsapp-notifier.log")
+	Level   string `json:"level"`
+	Format  string `json:"format"`   // "json" or "text"
+	LogFile string `json:"log_file"` // Path to log file (e.g., "./log/whatsapp-notifier.log")
+
+	MaxSizeMB  int `json:"max_size_mb"`  // Rotate the log file once it reaches this size (0 disables rotation)
+	MaxBackups int `json:"max_backups"`  // Rotated log files to keep (0 keeps all)
+	MaxAgeDays int `json:"max_age_days"` // Delete rotated log files older than this many days (0 keeps them regardless of age)
 }
 
 // SecurityConfig holds security-specific configuration
 type SecurityConfig struct {
 	// API Keys - sent by clients for authentication
-	APIKeys []string
+	APIKeys []string `json:"api_keys"`
+
+	// Only accept API keys in the X-API-Key header, rejecting the api_key query parameter
+	APIKeyHeaderOnly bool `json:"api_key_header_only"`
+
+	// API keys (a subset of APIKeys) allowed to send messages verbatim with "raw": true
+	RawAPIKeys []string `json:"raw_api_keys"`
+
+	// Reject webhook deliveries with another provider's signature header or an extra signature that doesn't verify
+	StrictSignatureHeaders bool `json:"strict_signature_headers"`
+}
+
+// RateLimitConfig holds HTTP request rate limiting configuration
+type RateLimitConfig struct {
+	RequestsPerMinute int           `json:"requests_per_minute"` // Requests allowed per client IP in each window
+	WindowSize        time.Duration `json:"window_size"`         // Window after which a client's allowance is refilled
+	PerKey            int           `json:"per_key"`             // Requests allowed per API key in each window; 0 limits API key requests by client IP
+	SweepInterval     time.Duration `json:"sweep_interval"`      // How often buckets of clients idle for a few windows are evicted
 }
 
 // GiteaConfig holds Gitea webhook configuration
 type GiteaConfig struct {
-	WebhookSecret string // Secret for webhook validation
-	Recipient     string // WhatsApp JID to send notifications to
+	WebhookSecret   string        `json:"webhook_secret"`   // Secret for webhook validation
+	Recipient       string        `json:"recipient"`        // WhatsApp JID to send notifications to
+	Priority        string        `json:"priority"`         // Delivery priority for notifications: low, normal or urgent
+	DigestInterval  time.Duration `json:"digest_interval"`  // Send notifications as a combined digest every interval (0 sends each event)
+	PayloadSecret   bool          `json:"payload_secret"`   // Accept the payload's secret field when no signature header is sent (older Gitea)
+	MessageTemplate string        `json:"message_template"` // Go template overriding the push notification format (empty uses the built-in one)
+	SignatureHeader string        `json:"signature_header"` // Header carrying the hex signature, for proxies that rename it
 }
 
 // GitHubConfig holds GitHub webhook configuration
 type GitHubConfig struct {
-	WebhookSecret string // Secret for webhook validation
-	Recipient     string // WhatsApp JID to send notifications to
+	WebhookSecret   string        `json:"webhook_secret"`   // Secret for webhook validation
+	Recipient       string        `json:"recipient"`        // WhatsApp JID to send notifications to
+	Priority        string        `json:"priority"`         // Delivery priority for notifications: low, normal or urgent
+	DigestInterval  time.Duration `json:"digest_interval"`  // Send notifications as a combined digest every interval (0 sends each event)
+	MessageTemplate string        `json:"message_template"` // Go template overriding the push notification format (empty uses the built-in one)
+	SignatureHeader string        `json:"signature_header"` // Header carrying the "sha256=" signature, for proxies that rename it
+}
+
+// BitbucketConfig holds Bitbucket webhook configuration
+type BitbucketConfig struct {
+	WebhookSecret  string        `json:"webhook_secret"`  // Shared secret expected in the ?secret= query parameter
+	Recipient      string        `json:"recipient"`       // WhatsApp JID to send notifications to
+	Priority       string        `json:"priority"`        // Delivery priority for notifications: low, normal or urgent
+	DigestInterval time.Duration `json:"digest_interval"` // Send notifications as a combined digest every interval (0 sends each event)
+}
+
+// JenkinsConfig holds Jenkins webhook configuration
+type JenkinsConfig struct {
+	WebhookToken   string        `json:"webhook_token"`   // Shared token expected in the X-Jenkins-Token header
+	Recipient      string        `json:"recipient"`       // WhatsApp JID to send notifications to
+	Priority       string        `json:"priority"`        // Delivery priority for notifications: low, normal or urgent
+	DigestInterval time.Duration `json:"digest_interval"` // Send notifications as a combined digest every interval (0 sends each event)
+}
+
+// AlertmanagerConfig holds Prometheus Alertmanager webhook configuration
+type AlertmanagerConfig struct {
+	BearerToken string `json:"bearer_token"` // Token expected in the Authorization: Bearer header (empty disables verification)
+	Recipient   string `json:"recipient"`    // WhatsApp JID to send notifications to
+	Priority    string `json:"priority"`     // Delivery priority for notifications: low, normal or urgent
+}
+
+// CustomWebhookConfig holds configuration of the generic templated webhook
+type CustomWebhookConfig struct {
+	Template        string `json:"template"`         // text/template rendered against the JSON body (empty disables the endpoint)
+	Secret          string `json:"secret"`           // HMAC SHA256 secret used to verify the signature header
+	SignatureHeader string `json:"signature_header"` // Header carrying the hex signature
+	SignaturePrefix string `json:"signature_prefix"` // Prefix before the hex signature, e.g. "sha256="
+	Recipient       string `json:"recipient"`        // WhatsApp JID used when the body has no "recipient"
+	Priority        string `json:"priority"`         // Delivery priority for notifications: low, normal or urgent
 }
 
-// Load loads configuration from environment variables with sensible defaults
+// RoutingConfig holds webhook notification routing and delivery configuration
+type RoutingConfig struct {
+	KeywordRoutes []KeywordRoute `json:"keyword_routes"` // Routes matched against commit messages, in order
+	Mode          string         `json:"mode"`           // "append" (also send to default recipient) or "replace"
+	MaxAge        time.Duration  `json:"max_age"`        // Reject webhook deliveries older than this (0 disables)
+
+	DuplicateWindow time.Duration     `json:"duplicate_window"` // Ignore repeated deliveries of the same delivery ID within this window (0 disables)
+	ContentDedupe   time.Duration     `json:"content_dedupe"`   // Suppress a notification identical to the last one sent to a recipient within this window (0 disables)
+	HistorySize     int               `json:"history_size"`     // Number of recent deliveries kept for replay (0 disables)
+	RepoIcons       map[string]string `json:"repo_icons"`       // Repository full name (lowercase) to the emoji leading its notifications
+
+	ShowMergeCommits     bool   `json:"show_merge_commits"`     // List merge commits (labelled) in push notifications instead of hiding them
+	BranchDisplayPattern string `json:"branch_display_pattern"` // Regex extracting the displayed branch name (first group, else the match)
+	PusherSource         string `json:"pusher_source"`          // Identity shown as the pusher: "committer", "author" or "pusher"
+	FileChangesMode      string `json:"file_changes_mode"`      // "list" shows changed file names, "counts" only the totals
+	ForcePushRecipient   string `json:"force_push_recipient"`   // Additional recipient of force-push notifications (empty disables)
+
+	BranchFilter []string `json:"branch_filter"` // Glob patterns of branches that trigger notifications (empty allows all)
+}
+
+// ConfirmConfig holds configuration of signed receipt confirmation links
+type ConfirmConfig struct {
+	Secret      string        `json:"secret"`       // HMAC SHA256 key signing confirmation tokens (empty disables confirmation links)
+	BaseURL     string        `json:"base_url"`     // Public base URL of this service, used to build the links
+	TTL         time.Duration `json:"ttl"`          // How long a confirmation link stays valid
+	CallbackURL string        `json:"callback_url"` // URL notified with a POST on each confirmation (empty disables)
+}
+
+// ForwardConfig holds configuration of forwarding incoming messages to a downstream endpoint
+type ForwardConfig struct {
+	URL           string        `json:"url"`            // Endpoint each incoming message is POSTed to (empty disables forwarding)
+	Timeout       time.Duration `json:"timeout"`        // Timeout of each delivery attempt
+	MaxRetries    int           `json:"max_retries"`    // Retries after a failed attempt before the event is dead-lettered
+	RetryInterval time.Duration `json:"retry_interval"` // Wait before the first retry, doubled after each further failure
+	QueueSize     int           `json:"queue_size"`     // Events buffered for delivery
+
+	DeadLetterFile string `json:"dead_letter_file"` // JSONL file for undeliverable events, rotated like LOG_FILE (empty disables)
+}
+
+// AlertConfig holds configuration for alert-style webhook notifications
+type AlertConfig struct {
+	SeverityEmoji map[string]string `json:"severity_emoji"` // Severity (lowercase) to emoji/prefix; merged over built-in defaults
+}
+
+// KeywordRoute routes notifications whose commit messages match Pattern to Recipient.
+// Patterns prefixed with "re:" are regular expressions; others are plain substrings.
+type KeywordRoute struct {
+	Pattern   string `json:"pattern"`
+	Recipient string `json:"recipient"`
+}
+
+// Load loads configuration from environment variables with sensible defaults.
+// If CONFIG_FILE names a JSON file it is applied first, and set environment
+// variables override it.
 func Load() (*Config, error) {
 	// Try to load .env file (ignore errors - it's optional)
 	_ = godotenv.Load(".env")
 
-	cfg := &Config{
+	return load()
+}
+
+// Reload re-reads the .env file and loads the configuration again. Values in the
+// file replace those already in the environment, so edits take effect; variables
+// removed from the file keep their previous value until the process restarts.
+// CONFIG_FILE is re-read as well.
+func Reload() (*Config, error) {
+	_ = godotenv.Overload(".env")
+
+	return load()
+}
+
+// load builds and validates the configuration from the defaults, CONFIG_FILE and the environment
+func load() (*Config, error) {
+	cfg := defaultConfig()
+	if err := loadConfigFile(cfg); err != nil {
+		return nil, err
+	}
+	applyEnv(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// defaultConfig returns the configuration used for settings that are neither in CONFIG_FILE nor in the environment
+func defaultConfig() *Config {
+	return &Config{
 		Server: ServerConfig{
-			Host:            getEnv("SERVER_HOST", ""),
-			Port:            getEnvAsInt("SERVER_PORT", 8080),
-			ReadTimeout:     getEnvAsDuration("SERVER_READ_TIMEOUT", 15*time.Second),
-			WriteTimeout:    getEnvAsDuration("SERVER_WRITE_TIMEOUT", 15*time.Second),
-			ShutdownTimeout: getEnvAsDuration("SERVER_SHUTDOWN_TIMEOUT", 10*time.Second),
+			Host:            "",
+			Port:            8080,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+			DebugMode:       false,
+			JSONBufferSize:  1 << 20,
+			JSONNotFound:    true,
+
+			SendSuccessStatus: 202,
+			DegradedQueueAge:  time.Minute,
+			HealthProbeJID:    "",
+			HealthProbeTTL:    30 * time.Second,
 		},
 		Database: DatabaseConfig{
-			Driver: getEnv("DB_DRIVER", "sqlite3"),
-			DSN:    getEnv("DB_DSN", "file:mywhatsapp.db?_foreign_keys=on"),
+			Driver: "sqlite3",
+			DSN:    "file:mywhatsapp.db?_foreign_keys=on",
 		},
 		WhatsApp: WhatsAppConfig{
-			LogLevel:   getEnv("WHATSAPP_LOG_LEVEL", "INFO"),
-			DeviceName: getEnv("WHATSAPP_DEVICE_NAME", "macOS"),
+			LogLevel:   "INFO",
+			DeviceName: "macOS",
+
+			StripJIDDeviceSuffix: true,
+			RecipientCooldown:    0,
+			MarkForwarded:        false,
+			ReadyGracePeriod:     0,
+			MaxMediaSize:         16 << 20,
+			DisableLinkPreviews:  false,
+			MessageFooter:        "",
+			CheckGroupMembership: true,
+			SenderAvatarTTL:      0,
+			MatchDisappearing:    true,
+			TypingDelay:          2 * time.Second,
+			SubscribePresence:    false,
+			DirectoryCacheTTL:    5 * time.Minute,
+			DirectoryCacheMax:    10000,
+			ReceiptTTL:           24 * time.Hour,
+			BulkConcurrency:      5,
+
+			SendConcurrency:       8,
+			LowPriorityQueueLimit: 100,
+
+			Reconnect: ReconnectConfig{
+				MaxRetries:      10,
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     5 * time.Minute,
+				Multiplier:      1.5,
+			},
+			ReconnectAlertJID: "",
+			CheckRecipients:   false,
+
+			ConnectionHistorySize: 100,
+
+			QRMaxAttempts:  5,
+			QRAttemptDelay: 5 * time.Second,
+			QRTimeout:      60 * time.Second,
 		},
 		Log: LogConfig{
-			Level:   getEnv("LOG_LEVEL", "info"),
-			Format:  getEnv("LOG_FORMAT", "text"),
-			LogFile: getEnv("LOG_FILE", ""),
+			Level:   "info",
+			Format:  "text",
+			LogFile: "",
+
+			MaxSizeMB:  100,
+			MaxBackups: 0,
+			MaxAgeDays: 0,
 		},
 		Security: SecurityConfig{
 			// API Keys that clients use to authenticate
-			APIKeys: getEnvAsSlice("API_KEYS", []string{}),
+			APIKeys:          []string{},
+			APIKeyHeaderOnly: false,
+			RawAPIKeys:       []string{},
+
+			StrictSignatureHeaders: false,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: 60,
+			WindowSize:        time.Minute,
+			PerKey:            0,
+			SweepInterval:     5 * time.Minute,
 		},
 		Gitea: GiteaConfig{
-			WebhookSecret: getEnv("GITEA_WEBHOOK_SECRET", ""),
-			Recipient:     getEnv("GITEA_RECIPIENT", ""),
+			WebhookSecret: "",
+			Recipient:     "",
+			Priority:      "normal",
+
+			DigestInterval: 0,
+			PayloadSecret:  false,
+
+			MessageTemplate: "",
+			SignatureHeader: "X-Gitea-Signature",
 		},
 		GitHub: GitHubConfig{
-			WebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
-			Recipient:     getEnv("GITHUB_RECIPIENT", ""),
+			WebhookSecret: "",
+			Recipient:     "",
+			Priority:      "normal",
+
+			DigestInterval: 0,
+
+			MessageTemplate: "",
+			SignatureHeader: "X-Hub-Signature-256",
 		},
-	}
+		Bitbucket: BitbucketConfig{
+			WebhookSecret: "",
+			Recipient:     "",
+			Priority:      "normal",
 
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+			DigestInterval: 0,
+		},
+		Jenkins: JenkinsConfig{
+			WebhookToken: "",
+			Recipient:    "",
+			Priority:     "normal",
+
+			DigestInterval: 0,
+		},
+		Alertmanager: AlertmanagerConfig{
+			BearerToken: "",
+			Recipient:   "",
+			Priority:    "normal",
+		},
+		Custom: CustomWebhookConfig{
+			Template:        "",
+			Secret:          "",
+			SignatureHeader: "X-Signature-256",
+			SignaturePrefix: "sha256=",
+			Recipient:       "",
+			Priority:        "normal",
+		},
+		Routing: RoutingConfig{
+			KeywordRoutes: []KeywordRoute{},
+			Mode:          "append",
+			MaxAge:        0,
+
+			DuplicateWindow: time.Minute,
+			ContentDedupe:   0,
+			HistorySize:     20,
+			RepoIcons:       map[string]string{},
+
+			ShowMergeCommits:     true,
+			BranchDisplayPattern: "",
+			PusherSource:         "committer",
+			FileChangesMode:      "list",
+			ForcePushRecipient:   "",
+
+			BranchFilter: []string{},
+		},
+		Alerts: AlertConfig{
+			SeverityEmoji: map[string]string{},
+		},
+		Confirm: ConfirmConfig{
+			Secret:      "",
+			BaseURL:     "",
+			TTL:         24 * time.Hour,
+			CallbackURL: "",
+		},
+		Forward: ForwardConfig{
+			URL:           "",
+			Timeout:       10 * time.Second,
+			MaxRetries:    5,
+			RetryInterval: time.Second,
+			QueueSize:     1000,
+
+			DeadLetterFile: "",
+		},
 	}
+}
 
-	return cfg, nil
+// applyEnv overrides settings with the environment variables that are set
+func applyEnv(cfg *Config) {
+	cfg.Server.Host = getEnv("SERVER_HOST", cfg.Server.Host)
+	cfg.Server.Port = getEnvAsInt("SERVER_PORT", cfg.Server.Port)
+	cfg.Server.ReadTimeout = getEnvAsDuration("SERVER_READ_TIMEOUT", cfg.Server.ReadTimeout)
+	cfg.Server.WriteTimeout = getEnvAsDuration("SERVER_WRITE_TIMEOUT", cfg.Server.WriteTimeout)
+	cfg.Server.ShutdownTimeout = getEnvAsDuration("SERVER_SHUTDOWN_TIMEOUT", cfg.Server.ShutdownTimeout)
+	cfg.Server.DebugMode = getEnvAsBool("DEBUG_MODE", cfg.Server.DebugMode)
+	cfg.Server.JSONBufferSize = getEnvAsInt("SERVER_JSON_BUFFER_SIZE", cfg.Server.JSONBufferSize)
+	cfg.Server.JSONNotFound = getEnvAsBool("SERVER_JSON_NOT_FOUND", cfg.Server.JSONNotFound)
+
+	cfg.Server.SendSuccessStatus = getEnvAsInt("SEND_SUCCESS_STATUS", cfg.Server.SendSuccessStatus)
+	cfg.Server.DegradedQueueAge = getEnvAsDuration("HEALTH_DEGRADED_QUEUE_AGE", cfg.Server.DegradedQueueAge)
+	cfg.Server.HealthProbeJID = getEnv("HEALTH_PROBE_JID", cfg.Server.HealthProbeJID)
+	cfg.Server.HealthProbeTTL = getEnvAsDuration("HEALTH_PROBE_TTL", cfg.Server.HealthProbeTTL)
+
+	cfg.Database.Driver = getEnv("DB_DRIVER", cfg.Database.Driver)
+	cfg.Database.DSN = getEnv("DB_DSN", cfg.Database.DSN)
+
+	cfg.WhatsApp.LogLevel = getEnv("WHATSAPP_LOG_LEVEL", cfg.WhatsApp.LogLevel)
+	cfg.WhatsApp.DeviceName = getEnv("WHATSAPP_DEVICE_NAME", cfg.WhatsApp.DeviceName)
+
+	cfg.WhatsApp.StripJIDDeviceSuffix = getEnvAsBool("WHATSAPP_STRIP_JID_DEVICE_SUFFIX", cfg.WhatsApp.StripJIDDeviceSuffix)
+	cfg.WhatsApp.RecipientCooldown = getEnvAsDuration("WHATSAPP_RECIPIENT_COOLDOWN", cfg.WhatsApp.RecipientCooldown)
+	cfg.WhatsApp.MarkForwarded = getEnvAsBool("WHATSAPP_MARK_FORWARDED", cfg.WhatsApp.MarkForwarded)
+	cfg.WhatsApp.ReadyGracePeriod = getEnvAsDuration("WHATSAPP_READY_GRACE_PERIOD", cfg.WhatsApp.ReadyGracePeriod)
+	cfg.WhatsApp.MaxMediaSize = getEnvAsInt("WHATSAPP_MAX_MEDIA_SIZE", cfg.WhatsApp.MaxMediaSize)
+	cfg.WhatsApp.DisableLinkPreviews = getEnvAsBool("DISABLE_LINK_PREVIEWS", cfg.WhatsApp.DisableLinkPreviews)
+	cfg.WhatsApp.MessageFooter = getEnv("MESSAGE_FOOTER", cfg.WhatsApp.MessageFooter)
+	cfg.WhatsApp.CheckGroupMembership = getEnvAsBool("WHATSAPP_CHECK_GROUP_MEMBERSHIP", cfg.WhatsApp.CheckGroupMembership)
+	cfg.WhatsApp.SenderAvatarTTL = getEnvAsDuration("WHATSAPP_SENDER_AVATAR_TTL", cfg.WhatsApp.SenderAvatarTTL)
+	cfg.WhatsApp.MatchDisappearing = getEnvAsBool("WHATSAPP_MATCH_DISAPPEARING_TIMER", cfg.WhatsApp.MatchDisappearing)
+	cfg.WhatsApp.TypingDelay = getEnvAsDuration("WHATSAPP_TYPING_DELAY", cfg.WhatsApp.TypingDelay)
+	cfg.WhatsApp.SubscribePresence = getEnvAsBool("WHATSAPP_SUBSCRIBE_PRESENCE", cfg.WhatsApp.SubscribePresence)
+	cfg.WhatsApp.DirectoryCacheTTL = getEnvAsDuration("WHATSAPP_DIRECTORY_CACHE_TTL", cfg.WhatsApp.DirectoryCacheTTL)
+	cfg.WhatsApp.DirectoryCacheMax = getEnvAsInt("WHATSAPP_DIRECTORY_CACHE_MAX", cfg.WhatsApp.DirectoryCacheMax)
+	cfg.WhatsApp.ReceiptTTL = getEnvAsDuration("WHATSAPP_RECEIPT_TTL", cfg.WhatsApp.ReceiptTTL)
+	cfg.WhatsApp.BulkConcurrency = getEnvAsInt("WHATSAPP_BULK_CONCURRENCY", cfg.WhatsApp.BulkConcurrency)
+
+	cfg.WhatsApp.SendConcurrency = getEnvAsInt("WHATSAPP_SEND_CONCURRENCY", cfg.WhatsApp.SendConcurrency)
+	cfg.WhatsApp.LowPriorityQueueLimit = getEnvAsInt("WHATSAPP_LOW_PRIORITY_QUEUE_LIMIT", cfg.WhatsApp.LowPriorityQueueLimit)
+
+	cfg.WhatsApp.Reconnect.MaxRetries = getEnvAsInt("WHATSAPP_RECONNECT_MAX_RETRIES", cfg.WhatsApp.Reconnect.MaxRetries)
+	cfg.WhatsApp.Reconnect.InitialInterval = getEnvAsDuration("WHATSAPP_RECONNECT_INITIAL_INTERVAL", cfg.WhatsApp.Reconnect.InitialInterval)
+	cfg.WhatsApp.Reconnect.MaxInterval = getEnvAsDuration("WHATSAPP_RECONNECT_MAX_INTERVAL", cfg.WhatsApp.Reconnect.MaxInterval)
+	cfg.WhatsApp.Reconnect.Multiplier = getEnvAsFloat("WHATSAPP_RECONNECT_MULTIPLIER", cfg.WhatsApp.Reconnect.Multiplier)
+	cfg.WhatsApp.ReconnectAlertJID = getEnv("WHATSAPP_RECONNECT_ALERT_JID", cfg.WhatsApp.ReconnectAlertJID)
+	cfg.WhatsApp.CheckRecipients = getEnvAsBool("WHATSAPP_CHECK_RECIPIENTS", cfg.WhatsApp.CheckRecipients)
+
+	cfg.WhatsApp.ConnectionHistorySize = getEnvAsInt("WHATSAPP_CONNECTION_HISTORY_SIZE", cfg.WhatsApp.ConnectionHistorySize)
+
+	cfg.WhatsApp.QRMaxAttempts = getEnvAsInt("WHATSAPP_QR_MAX_ATTEMPTS", cfg.WhatsApp.QRMaxAttempts)
+	cfg.WhatsApp.QRAttemptDelay = getEnvAsDuration("WHATSAPP_QR_ATTEMPT_DELAY", cfg.WhatsApp.QRAttemptDelay)
+	cfg.WhatsApp.QRTimeout = getEnvAsDuration("WHATSAPP_QR_TIMEOUT", cfg.WhatsApp.QRTimeout)
+
+	cfg.Log.Level = getEnv("LOG_LEVEL", cfg.Log.Level)
+	cfg.Log.Format = getEnv("LOG_FORMAT", cfg.Log.Format)
+	cfg.Log.LogFile = getEnv("LOG_FILE", cfg.Log.LogFile)
+
+	cfg.Log.MaxSizeMB = getEnvAsInt("LOG_MAX_SIZE_MB", cfg.Log.MaxSizeMB)
+	cfg.Log.MaxBackups = getEnvAsInt("LOG_MAX_BACKUPS", cfg.Log.MaxBackups)
+	cfg.Log.MaxAgeDays = getEnvAsInt("LOG_MAX_AGE_DAYS", cfg.Log.MaxAgeDays)
+
+	cfg.Security.APIKeys = getEnvAsSlice("API_KEYS", cfg.Security.APIKeys)
+	cfg.Security.APIKeyHeaderOnly = getEnvAsBool("APIKEY_HEADER_ONLY", cfg.Security.APIKeyHeaderOnly)
+	cfg.Security.RawAPIKeys = getEnvAsSlice("RAW_API_KEYS", cfg.Security.RawAPIKeys)
+
+	cfg.Security.StrictSignatureHeaders = getEnvAsBool("WEBHOOK_STRICT_SIGNATURES", cfg.Security.StrictSignatureHeaders)
+
+	cfg.RateLimit.RequestsPerMinute = getEnvAsInt("RATE_LIMIT_RPM", cfg.RateLimit.RequestsPerMinute)
+	cfg.RateLimit.WindowSize = getEnvAsDuration("RATE_LIMIT_WINDOW", cfg.RateLimit.WindowSize)
+	cfg.RateLimit.PerKey = getEnvAsInt("RATE_LIMIT_PER_KEY", cfg.RateLimit.PerKey)
+	cfg.RateLimit.SweepInterval = getEnvAsDuration("RATE_LIMIT_SWEEP_INTERVAL", cfg.RateLimit.SweepInterval)
+
+	cfg.Gitea.WebhookSecret = getEnv("GITEA_WEBHOOK_SECRET", cfg.Gitea.WebhookSecret)
+	cfg.Gitea.Recipient = getEnv("GITEA_RECIPIENT", cfg.Gitea.Recipient)
+	cfg.Gitea.Priority = getEnv("GITEA_PRIORITY", cfg.Gitea.Priority)
+
+	cfg.Gitea.DigestInterval = getEnvAsDuration("GITEA_DIGEST_INTERVAL", cfg.Gitea.DigestInterval)
+	cfg.Gitea.PayloadSecret = getEnvAsBool("GITEA_ALLOW_PAYLOAD_SECRET", cfg.Gitea.PayloadSecret)
+
+	cfg.Gitea.MessageTemplate = getEnv("GITEA_MESSAGE_TEMPLATE", cfg.Gitea.MessageTemplate)
+	cfg.Gitea.SignatureHeader = getEnv("GITEA_SIGNATURE_HEADER", cfg.Gitea.SignatureHeader)
+
+	cfg.GitHub.WebhookSecret = getEnv("GITHUB_WEBHOOK_SECRET", cfg.GitHub.WebhookSecret)
+	cfg.GitHub.Recipient = getEnv("GITHUB_RECIPIENT", cfg.GitHub.Recipient)
+	cfg.GitHub.Priority = getEnv("GITHUB_PRIORITY", cfg.GitHub.Priority)
+
+	cfg.GitHub.DigestInterval = getEnvAsDuration("GITHUB_DIGEST_INTERVAL", cfg.GitHub.DigestInterval)
+
+	cfg.GitHub.MessageTemplate = getEnv("GITHUB_MESSAGE_TEMPLATE", cfg.GitHub.MessageTemplate)
+	cfg.GitHub.SignatureHeader = getEnv("GITHUB_SIGNATURE_HEADER", cfg.GitHub.SignatureHeader)
+
+	cfg.Bitbucket.WebhookSecret = getEnv("BITBUCKET_WEBHOOK_SECRET", cfg.Bitbucket.WebhookSecret)
+	cfg.Bitbucket.Recipient = getEnv("BITBUCKET_RECIPIENT", cfg.Bitbucket.Recipient)
+	cfg.Bitbucket.Priority = getEnv("BITBUCKET_PRIORITY", cfg.Bitbucket.Priority)
+
+	cfg.Bitbucket.DigestInterval = getEnvAsDuration("BITBUCKET_DIGEST_INTERVAL", cfg.Bitbucket.DigestInterval)
+
+	cfg.Jenkins.WebhookToken = getEnv("JENKINS_WEBHOOK_TOKEN", cfg.Jenkins.WebhookToken)
+	cfg.Jenkins.Recipient = getEnv("JENKINS_RECIPIENT", cfg.Jenkins.Recipient)
+	cfg.Jenkins.Priority = getEnv("JENKINS_PRIORITY", cfg.Jenkins.Priority)
+
+	cfg.Jenkins.DigestInterval = getEnvAsDuration("JENKINS_DIGEST_INTERVAL", cfg.Jenkins.DigestInterval)
+
+	cfg.Alertmanager.BearerToken = getEnv("ALERTMANAGER_BEARER_TOKEN", cfg.Alertmanager.BearerToken)
+	cfg.Alertmanager.Recipient = getEnv("ALERTMANAGER_RECIPIENT", cfg.Alertmanager.Recipient)
+	cfg.Alertmanager.Priority = getEnv("ALERTMANAGER_PRIORITY", cfg.Alertmanager.Priority)
+
+	cfg.Custom.Template = getEnv("CUSTOM_WEBHOOK_TEMPLATE", cfg.Custom.Template)
+	cfg.Custom.Secret = getEnv("CUSTOM_WEBHOOK_SECRET", cfg.Custom.Secret)
+	cfg.Custom.SignatureHeader = getEnv("CUSTOM_WEBHOOK_SIGNATURE_HEADER", cfg.Custom.SignatureHeader)
+	cfg.Custom.SignaturePrefix = getEnv("CUSTOM_WEBHOOK_SIGNATURE_PREFIX", cfg.Custom.SignaturePrefix)
+	cfg.Custom.Recipient = getEnv("CUSTOM_WEBHOOK_RECIPIENT", cfg.Custom.Recipient)
+	cfg.Custom.Priority = getEnv("CUSTOM_WEBHOOK_PRIORITY", cfg.Custom.Priority)
+
+	cfg.Routing.KeywordRoutes = getEnvAsKeywordRoutes("WEBHOOK_KEYWORD_ROUTES", cfg.Routing.KeywordRoutes)
+	cfg.Routing.Mode = getEnv("WEBHOOK_KEYWORD_ROUTING_MODE", cfg.Routing.Mode)
+	cfg.Routing.MaxAge = getEnvAsDuration("WEBHOOK_MAX_AGE", cfg.Routing.MaxAge)
+
+	cfg.Routing.DuplicateWindow = getEnvAsDuration("WEBHOOK_DUPLICATE_WINDOW", cfg.Routing.DuplicateWindow)
+	cfg.Routing.ContentDedupe = getEnvAsDuration("WEBHOOK_CONTENT_DEDUPE_WINDOW", cfg.Routing.ContentDedupe)
+	cfg.Routing.HistorySize = getEnvAsInt("WEBHOOK_HISTORY_SIZE", cfg.Routing.HistorySize)
+	cfg.Routing.RepoIcons = getEnvAsMap("REPO_ICONS", "=", cfg.Routing.RepoIcons)
+
+	cfg.Routing.ShowMergeCommits = getEnvAsBool("SHOW_MERGE_COMMITS", cfg.Routing.ShowMergeCommits)
+	cfg.Routing.BranchDisplayPattern = getEnv("BRANCH_DISPLAY_PATTERN", cfg.Routing.BranchDisplayPattern)
+	cfg.Routing.PusherSource = getEnv("PUSHER_SOURCE", cfg.Routing.PusherSource)
+	cfg.Routing.FileChangesMode = getEnv("FILE_CHANGES_MODE", cfg.Routing.FileChangesMode)
+	cfg.Routing.ForcePushRecipient = getEnv("FORCE_PUSH_RECIPIENT", cfg.Routing.ForcePushRecipient)
+
+	cfg.Routing.BranchFilter = getEnvAsSlice("WEBHOOK_BRANCH_FILTER", cfg.Routing.BranchFilter)
+
+	cfg.Alerts.SeverityEmoji = getEnvAsMap("SEVERITY_EMOJI", ":", cfg.Alerts.SeverityEmoji)
+
+	cfg.Confirm.Secret = getEnv("CONFIRM_LINK_SECRET", cfg.Confirm.Secret)
+	cfg.Confirm.BaseURL = getEnv("CONFIRM_LINK_BASE_URL", cfg.Confirm.BaseURL)
+	cfg.Confirm.TTL = getEnvAsDuration("CONFIRM_LINK_TTL", cfg.Confirm.TTL)
+	cfg.Confirm.CallbackURL = getEnv("CONFIRM_CALLBACK_URL", cfg.Confirm.CallbackURL)
+
+	cfg.Forward.URL = getEnv("EVENT_FORWARD_URL", cfg.Forward.URL)
+	cfg.Forward.Timeout = getEnvAsDuration("EVENT_FORWARD_TIMEOUT", cfg.Forward.Timeout)
+	cfg.Forward.MaxRetries = getEnvAsInt("EVENT_FORWARD_MAX_RETRIES", cfg.Forward.MaxRetries)
+	cfg.Forward.RetryInterval = getEnvAsDuration("EVENT_FORWARD_RETRY_INTERVAL", cfg.Forward.RetryInterval)
+	cfg.Forward.QueueSize = getEnvAsInt("EVENT_FORWARD_QUEUE_SIZE", cfg.Forward.QueueSize)
+
+	cfg.Forward.DeadLetterFile = getEnv("EVENT_DEAD_LETTER_FILE", cfg.Forward.DeadLetterFile)
 }
 
 // Validate validates the configuration
@@ -132,6 +634,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
 
+	if c.Server.SendSuccessStatus != 200 && c.Server.SendSuccessStatus != 202 {
+		return fmt.Errorf("invalid SEND_SUCCESS_STATUS: %d (must be 200 or 202)", c.Server.SendSuccessStatus)
+	}
+
 	if c.Database.Driver == "" {
 		return fmt.Errorf("database driver is required")
 	}
@@ -152,9 +658,213 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, key := range c.Security.RawAPIKeys {
+		if !slices.Contains(c.Security.APIKeys, key) {
+			return fmt.Errorf("RAW_API_KEYS contains a key that isn't in API_KEYS")
+		}
+	}
+
+	if len(c.WhatsApp.MessageFooter) > 1024 {
+		return fmt.Errorf("invalid MESSAGE_FOOTER: %d characters (maximum 1024)", len(c.WhatsApp.MessageFooter))
+	}
+
+	// Reconnection validation
+	reconnect := c.WhatsApp.Reconnect
+	if reconnect.MaxRetries < 0 {
+		return fmt.Errorf("invalid WHATSAPP_RECONNECT_MAX_RETRIES: %d (must be 0 or more)", reconnect.MaxRetries)
+	}
+
+	if reconnect.InitialInterval <= 0 {
+		return fmt.Errorf("invalid WHATSAPP_RECONNECT_INITIAL_INTERVAL: %s (must be positive)", reconnect.InitialInterval)
+	}
+
+	if reconnect.MaxInterval < reconnect.InitialInterval {
+		return fmt.Errorf("invalid WHATSAPP_RECONNECT_MAX_INTERVAL: %s (must be at least WHATSAPP_RECONNECT_INITIAL_INTERVAL)", reconnect.MaxInterval)
+	}
+
+	if reconnect.Multiplier < 1 {
+		return fmt.Errorf("invalid WHATSAPP_RECONNECT_MULTIPLIER: %g (must be 1 or more)", reconnect.Multiplier)
+	}
+
+	// QR authentication validation
+	if c.WhatsApp.QRMaxAttempts <= 0 {
+		return fmt.Errorf("invalid WHATSAPP_QR_MAX_ATTEMPTS: %d (must be positive)", c.WhatsApp.QRMaxAttempts)
+	}
+
+	for name, value := range map[string]time.Duration{"WHATSAPP_QR_ATTEMPT_DELAY": c.WhatsApp.QRAttemptDelay, "WHATSAPP_QR_TIMEOUT": c.WhatsApp.QRTimeout} {
+		if value <= 0 {
+			return fmt.Errorf("invalid %s: %s (must be positive)", name, value)
+		}
+	}
+
+	if c.WhatsApp.DirectoryCacheTTL < 0 {
+		return fmt.Errorf("invalid WHATSAPP_DIRECTORY_CACHE_TTL: %s (must be 0 or more)", c.WhatsApp.DirectoryCacheTTL)
+	}
+	if c.WhatsApp.DirectoryCacheMax < 0 {
+		return fmt.Errorf("invalid WHATSAPP_DIRECTORY_CACHE_MAX: %d (must be 0 or more)", c.WhatsApp.DirectoryCacheMax)
+	}
+
+	// Log rotation validation
+	for name, value := range map[string]int{"LOG_MAX_SIZE_MB": c.Log.MaxSizeMB, "LOG_MAX_BACKUPS": c.Log.MaxBackups, "LOG_MAX_AGE_DAYS": c.Log.MaxAgeDays} {
+		if value < 0 {
+			return fmt.Errorf("invalid %s: %d (must be 0 or more)", name, value)
+		}
+	}
+
+	// Rate limit validation
+	if c.RateLimit.RequestsPerMinute <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_RPM: %d (must be positive)", c.RateLimit.RequestsPerMinute)
+	}
+
+	if c.RateLimit.WindowSize <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_WINDOW: %s (must be positive)", c.RateLimit.WindowSize)
+	}
+
+	if c.RateLimit.SweepInterval <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_SWEEP_INTERVAL: %s (must be positive)", c.RateLimit.SweepInterval)
+	}
+
+	if c.RateLimit.PerKey < 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_PER_KEY: %d (must be 0 or more)", c.RateLimit.PerKey)
+	}
+
+	// Webhook priority validation
+	for name, priority := range map[string]string{"GITEA_PRIORITY": c.Gitea.Priority, "GITHUB_PRIORITY": c.GitHub.Priority, "BITBUCKET_PRIORITY": c.Bitbucket.Priority, "JENKINS_PRIORITY": c.Jenkins.Priority, "ALERTMANAGER_PRIORITY": c.Alertmanager.Priority, "CUSTOM_WEBHOOK_PRIORITY": c.Custom.Priority} {
+		switch priority {
+		case "low", "normal", "urgent":
+		default:
+			return fmt.Errorf("invalid %s: '%s' (must be one of: low, normal, urgent)", name, priority)
+		}
+	}
+
+	if c.Routing.BranchDisplayPattern != "" {
+		if _, err := regexp.Compile(c.Routing.BranchDisplayPattern); err != nil {
+			return fmt.Errorf("invalid BRANCH_DISPLAY_PATTERN: %w", err)
+		}
+	}
+
+	for _, pattern := range c.Routing.BranchFilter {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid WEBHOOK_BRANCH_FILTER pattern '%s': %w", pattern, err)
+		}
+	}
+
+	switch c.Routing.PusherSource {
+	case "committer", "author", "pusher":
+	default:
+		return fmt.Errorf("invalid PUSHER_SOURCE: '%s' (must be one of: committer, author, pusher)", c.Routing.PusherSource)
+	}
+	if c.Routing.FileChangesMode != "list" && c.Routing.FileChangesMode != "counts" {
+		return fmt.Errorf("invalid FILE_CHANGES_MODE: '%s' (must be list or counts)", c.Routing.FileChangesMode)
+	}
+
+	// Signature header validation
+	for name, header := range map[string]string{"GITEA_SIGNATURE_HEADER": c.Gitea.SignatureHeader, "GITHUB_SIGNATURE_HEADER": c.GitHub.SignatureHeader} {
+		if !headerNamePattern.MatchString(header) {
+			return fmt.Errorf("invalid %s: '%s' (must be a header name of letters, digits and dashes)", name, header)
+		}
+	}
+
+	// Message template validation
+	for name, tmpl := range map[string]string{"GITEA_MESSAGE_TEMPLATE": c.Gitea.MessageTemplate, "GITHUB_MESSAGE_TEMPLATE": c.GitHub.MessageTemplate} {
+		if tmpl == "" {
+			continue
+		}
+		if _, err := template.New(name).Funcs(models.MessageTemplateFuncs).Parse(tmpl); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	// Custom webhook validation
+	if c.Custom.Template != "" {
+		if _, err := template.New("custom").Parse(c.Custom.Template); err != nil {
+			return fmt.Errorf("invalid CUSTOM_WEBHOOK_TEMPLATE: %w", err)
+		}
+	}
+
+	// Confirmation link validation
+	if c.Confirm.Secret != "" {
+		if !isHTTPURL(c.Confirm.BaseURL) {
+			return fmt.Errorf("invalid CONFIRM_LINK_BASE_URL: '%s' (must be an http or https URL when CONFIRM_LINK_SECRET is set)", c.Confirm.BaseURL)
+		}
+		if c.Confirm.TTL <= 0 {
+			return fmt.Errorf("invalid CONFIRM_LINK_TTL: %s (must be positive)", c.Confirm.TTL)
+		}
+	}
+	if c.Confirm.CallbackURL != "" && !isHTTPURL(c.Confirm.CallbackURL) {
+		return fmt.Errorf("invalid CONFIRM_CALLBACK_URL: '%s' (must be an http or https URL)", c.Confirm.CallbackURL)
+	}
+
+	// Event forwarding validation
+	if c.Forward.URL != "" {
+		if !isHTTPURL(c.Forward.URL) {
+			return fmt.Errorf("invalid EVENT_FORWARD_URL: '%s' (must be an http or https URL)", c.Forward.URL)
+		}
+		if c.Forward.Timeout <= 0 {
+			return fmt.Errorf("invalid EVENT_FORWARD_TIMEOUT: %s (must be positive)", c.Forward.Timeout)
+		}
+		if c.Forward.MaxRetries < 0 {
+			return fmt.Errorf("invalid EVENT_FORWARD_MAX_RETRIES: %d (must be 0 or more)", c.Forward.MaxRetries)
+		}
+		if c.Forward.RetryInterval <= 0 {
+			return fmt.Errorf("invalid EVENT_FORWARD_RETRY_INTERVAL: %s (must be positive)", c.Forward.RetryInterval)
+		}
+		if c.Forward.QueueSize < 1 {
+			return fmt.Errorf("invalid EVENT_FORWARD_QUEUE_SIZE: %d (must be at least 1)", c.Forward.QueueSize)
+		}
+	}
+
+	// Keyword route validation
+	if c.Routing.Mode != "append" && c.Routing.Mode != "replace" {
+		return fmt.Errorf("invalid WEBHOOK_KEYWORD_ROUTING_MODE: '%s' (must be append or replace)", c.Routing.Mode)
+	}
+	for _, route := range c.Routing.KeywordRoutes {
+		if route.Pattern == "" || route.Recipient == "" {
+			return fmt.Errorf("invalid keyword route '%s=%s': pattern and recipient are required", route.Pattern, route.Recipient)
+		}
+		if expr, ok := strings.CutPrefix(route.Pattern, "re:"); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid keyword route pattern '%s': %w", route.Pattern, err)
+			}
+		}
+	}
+
 	return nil
 }
 
+// headerNamePattern matches conventional HTTP header names
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// isHTTPURL reports whether s is an absolute http or https URL
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Recipients returns every configured notification recipient JID with the settings that name it
+func (c *Config) Recipients() map[string][]string {
+	recipients := make(map[string][]string)
+	add := func(setting, jid string) {
+		if jid != "" {
+			recipients[jid] = append(recipients[jid], setting)
+		}
+	}
+
+	add("GITEA_RECIPIENT", c.Gitea.Recipient)
+	add("GITHUB_RECIPIENT", c.GitHub.Recipient)
+	add("BITBUCKET_RECIPIENT", c.Bitbucket.Recipient)
+	add("JENKINS_RECIPIENT", c.Jenkins.Recipient)
+	add("ALERTMANAGER_RECIPIENT", c.Alertmanager.Recipient)
+	add("CUSTOM_WEBHOOK_RECIPIENT", c.Custom.Recipient)
+	add("WHATSAPP_RECONNECT_ALERT_JID", c.WhatsApp.ReconnectAlertJID)
+	add("FORCE_PUSH_RECIPIENT", c.Routing.ForcePushRecipient)
+	for _, route := range c.Routing.KeywordRoutes {
+		add("WEBHOOK_KEYWORD_ROUTES", route.Recipient)
+	}
+
+	return recipients
+}
+
 // Address returns the server address in the format host:port
 func (s *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
@@ -183,6 +893,34 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -214,6 +952,45 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	return values
 }
 
+// getEnvAsMap parses comma-separated "key<sep>value" entries; keys are lowercased
+func getEnvAsMap(key, sep string, defaultValue map[string]string) map[string]string {
+	if os.Getenv(key) == "" {
+		return defaultValue
+	}
+
+	values := make(map[string]string)
+	for _, entry := range getEnvAsSlice(key, []string{}) {
+		k, v, found := strings.Cut(entry, sep)
+		if !found {
+			continue
+		}
+		values[strings.ToLower(trimSpace(k))] = trimSpace(v)
+	}
+	return values
+}
+
+// getEnvAsKeywordRoutes parses comma-separated "pattern=recipient" entries
+func getEnvAsKeywordRoutes(key string, defaultValue []KeywordRoute) []KeywordRoute {
+	if os.Getenv(key) == "" {
+		return defaultValue
+	}
+
+	routes := make([]KeywordRoute, 0)
+	for _, entry := range getEnvAsSlice(key, []string{}) {
+		// Split on the last '=' since JIDs never contain one but patterns might
+		idx := strings.LastIndex(entry, "=")
+		if idx == -1 {
+			routes = append(routes, KeywordRoute{Pattern: entry})
+			continue
+		}
+		routes = append(routes, KeywordRoute{
+			Pattern:   trimSpace(entry[:idx]),
+			Recipient: trimSpace(entry[idx+1:]),
+		})
+	}
+	return routes
+}
+
 func splitAndTrim(s, sep string) []string {
 	parts := make([]string, 0)
 	for _, part := range splitString(s, sep) {
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// loadConfigFile applies the JSON file named by CONFIG_FILE, if set, on top of cfg.
+// The file mirrors Config using the fields' json tags, e.g. {"server": {"port": 8080}};
+// settings it leaves out keep their current value. Durations are strings such as "15s".
+func loadConfigFile(cfg *Config) error {
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CONFIG_FILE: %w", err)
+	}
+
+	if err := decodeConfigFile(data, cfg); err != nil {
+		return fmt.Errorf("invalid CONFIG_FILE %s: %w", path, err)
+	}
+	return nil
+}
+
+// decodeConfigFile unmarshals a config file into cfg. Unlike json.Unmarshal it
+// accepts duration strings and rejects keys that don't match a setting.
+func decodeConfigFile(data []byte, cfg *Config) error {
+	return decodeConfigValue(data, reflect.ValueOf(cfg).Elem(), "")
+}
+
+// decodeConfigValue unmarshals a JSON value into dst; name is the value's dotted path for errors
+func decodeConfigValue(data json.RawMessage, dst reflect.Value, name string) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	switch {
+	case dst.Type() == durationType:
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return fmt.Errorf("%s: must be a duration string such as \"30s\"", name)
+		}
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		dst.SetInt(int64(d))
+	case dst.Kind() == reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			if name == "" {
+				return err
+			}
+			return fmt.Errorf("%s: must be an object", name)
+		}
+		for key, value := range fields {
+			path := key
+			if name != "" {
+				path = name + "." + key
+			}
+			field, ok := configField(dst, key)
+			if !ok {
+				return fmt.Errorf("unknown setting %s", path)
+			}
+			if err := decodeConfigValue(value, field, path); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := json.Unmarshal(data, dst.Addr().Interface()); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// configField returns the field of the struct v whose json tag is key
+func configField(v reflect.Value, key string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if tag == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+func TestLoadConfigFileWithEnvOverrides(t *testing.T) {
+	writeConfigFile(t, `{
+		"server": {"port": 9090, "read_timeout": "30s"},
+		"security": {"api_keys": ["file-api-key-1"]},
+		"github": {"recipient": "111@s.whatsapp.net"},
+		"routing": {
+			"keyword_routes": [{"pattern": "hotfix", "recipient": "222@s.whatsapp.net"}],
+			"show_merge_commits": false
+		}
+	}`)
+	t.Setenv("SERVER_PORT", "7070")
+	t.Setenv("GITHUB_RECIPIENT", "")
+
+	cfg, err := load()
+	if err != nil {
+		t.Fatalf("load() returned error: %v", err)
+	}
+
+	if cfg.Server.Port != 7070 {
+		t.Errorf("Server.Port = %d, want the environment's 7070", cfg.Server.Port)
+	}
+	if cfg.Server.ReadTimeout != 30*time.Second {
+		t.Errorf("Server.ReadTimeout = %v, want the file's 30s", cfg.Server.ReadTimeout)
+	}
+	if cfg.Server.WriteTimeout != 15*time.Second {
+		t.Errorf("Server.WriteTimeout = %v, want the default 15s", cfg.Server.WriteTimeout)
+	}
+	if len(cfg.Security.APIKeys) != 1 || cfg.Security.APIKeys[0] != "file-api-key-1" {
+		t.Errorf("Security.APIKeys = %v, want the file's keys", cfg.Security.APIKeys)
+	}
+	if cfg.GitHub.Recipient != "111@s.whatsapp.net" {
+		t.Errorf("GitHub.Recipient = %q, want the file's value when the variable is empty", cfg.GitHub.Recipient)
+	}
+	if len(cfg.Routing.KeywordRoutes) != 1 || cfg.Routing.KeywordRoutes[0].Pattern != "hotfix" {
+		t.Errorf("Routing.KeywordRoutes = %v, want the file's route", cfg.Routing.KeywordRoutes)
+	}
+	if cfg.Routing.ShowMergeCommits {
+		t.Error("Routing.ShowMergeCommits = true, want the file's false")
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown section", `{"sever": {"port": 8080}}`, "unknown setting sever"},
+		{"unknown field", `{"server": {"prot": 8080}}`, "unknown setting server.prot"},
+		{"numeric duration", `{"server": {"read_timeout": 30}}`, "server.read_timeout"},
+		{"invalid duration", `{"server": {"read_timeout": "soon"}}`, "server.read_timeout"},
+		{"wrong type", `{"server": {"port": "8080"}}`, "server.port"},
+		{"env variable names", `{"SERVER_PORT": 8080}`, "unknown setting SERVER_PORT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigFile(t, tt.content)
+			t.Setenv("API_KEYS", "test-api-key")
+
+			_, err := load()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("load() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ const (
 	ErrCodeConnectionFailed   ErrorCode = "CONNECTION_FAILED"
 	ErrCodeMessageSendFailed  ErrorCode = "MESSAGE_SEND_FAILED"
 	ErrCodeInvalidJID         ErrorCode = "INVALID_JID"
+	ErrCodeAlreadyPaired      ErrorCode = "ALREADY_PAIRED"
 
 	// Server errors
 	ErrCodeInternalError      ErrorCode = "INTERNAL_ERROR"
@@ -93,6 +94,8 @@ func getStatusCodeForError(code ErrorCode) int {
 		return http.StatusNotFound
 	case ErrCodeTooManyRequests:
 		return http.StatusTooManyRequests
+	case ErrCodeAlreadyPaired:
+		return http.StatusConflict
 	case ErrCodeClientNotConnected, ErrCodeServiceUnavailable:
 		return http.StatusServiceUnavailable
 	case ErrCodeInternalError, ErrCodeConnectionFailed, ErrCodeMessageSendFailed, ErrCodeDatabaseError:
@@ -129,11 +132,31 @@ func MessageSendFailed(err error) *AppError {
 	return Wrap(err, ErrCodeMessageSendFailed, "Failed to send message")
 }
 
+// SendQueueFull creates an error for low-priority sends shed while the send queue is full
+func SendQueueFull() *AppError {
+	return New(ErrCodeServiceUnavailable, "Send queue is full; low-priority messages are rejected until it drains, retry later or send with a higher priority")
+}
+
+// NotGroupMember creates an error for sends to a group the account isn't a member of
+func NotGroupMember(group string) *AppError {
+	return New(ErrCodeForbidden, fmt.Sprintf("Not a member of group %s", group))
+}
+
 // InvalidJID creates an invalid JID error
 func InvalidJID(jid string) *AppError {
 	return New(ErrCodeInvalidJID, fmt.Sprintf("Invalid WhatsApp JID: %s", jid))
 }
 
+// AlreadyPaired creates an error for pairing attempts while a session exists
+func AlreadyPaired() *AppError {
+	return New(ErrCodeAlreadyPaired, "WhatsApp client is already paired")
+}
+
+// NotFound creates a not found error
+func NotFound(message string) *AppError {
+	return New(ErrCodeNotFound, message)
+}
+
 // InternalError creates an internal server error
 func InternalError(err error) *AppError {
 	return Wrap(err, ErrCodeInternalError, "Internal server error")
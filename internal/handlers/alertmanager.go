@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// ProviderAlertmanager identifies Prometheus Alertmanager notifications
+const ProviderAlertmanager WebhookProvider = "Alertmanager"
+
+// maxAlertsPerMessage caps how many alerts of a group are listed in one message
+const maxAlertsPerMessage = 10
+
+// SetAlertmanagerConfig sets the Alertmanager bearer token, recipient and priority
+func (h *Handler) SetAlertmanagerConfig(token, recipient, priority string) {
+	h.alertmanagerToken = token
+	h.alertmanagerRecipient = recipient
+	h.alertmanagerPriority = models.Priority(priority).OrDefault()
+}
+
+// AlertmanagerWebhook handles Prometheus Alertmanager webhook requests
+func (h *Handler) AlertmanagerWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
+	config := WebhookConfig{
+		Provider: ProviderAlertmanager,
+		// Alertmanager doesn't sign payloads; its http_config can send a bearer token instead
+		SecretHeader:       "Authorization",
+		SecretHeaderPrefix: "Bearer ",
+		Secret:             h.alertmanagerToken,
+		Recipient:          h.alertmanagerRecipient,
+		Priority:           h.alertmanagerPriority,
+	}
+	h.webhookMutex.RUnlock()
+
+	parsePayload := func(body []byte) (WebhookPayload, error) {
+		var payload models.AlertmanagerPayload
+		err := json.Unmarshal(body, &payload)
+		return payload, err
+	}
+
+	h.handleWebhook(w, r, config, parsePayload)
+}
+
+// formatAlertmanagerMessage constructs one WhatsApp message for an Alertmanager alert group,
+// listing firing alerts before resolved ones
+func (h *Handler) formatAlertmanagerMessage(payload models.AlertmanagerPayload) string {
+	firing := make([]models.AlertmanagerAlert, 0, len(payload.Alerts))
+	resolved := make([]models.AlertmanagerAlert, 0)
+	for _, alert := range payload.Alerts {
+		if alert.Status == models.AlertmanagerStatusResolved {
+			resolved = append(resolved, alert)
+			continue
+		}
+		firing = append(firing, alert)
+	}
+
+	var sb strings.Builder
+	if len(firing) > 0 {
+		sb.WriteString(fmt.Sprintf("🔥 *[FIRING:%d]* %s\n", len(firing), payload.GetRepositoryName()))
+		if len(resolved) > 0 {
+			sb.WriteString(fmt.Sprintf("_%d resolved_\n", len(resolved)))
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("✅ *[RESOLVED:%d]* %s\n", len(resolved), payload.GetRepositoryName()))
+	}
+
+	// Alerts dropped by Alertmanager's max_alerts are only counted
+	hidden := payload.TruncatedAlerts
+	for i, alert := range append(firing, resolved...) {
+		if i >= maxAlertsPerMessage {
+			hidden += len(payload.Alerts) - maxAlertsPerMessage
+			break
+		}
+
+		severity := alert.Labels["severity"]
+		sb.WriteString(fmt.Sprintf("\n%s *%s*", h.emojiForSeverity(severity), alert.Labels["alertname"]))
+		if severity != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", severity))
+		}
+		if alert.Status == models.AlertmanagerStatusResolved && len(firing) > 0 {
+			sb.WriteString(" ✅ _resolved_")
+		}
+		sb.WriteString("\n")
+		if summary := alert.Annotations["summary"]; summary != "" {
+			sb.WriteString(summary + "\n")
+		}
+	}
+	if hidden > 0 {
+		sb.WriteString(fmt.Sprintf("\n_...and %d more alert(s)_\n", hidden))
+	}
+
+	if payload.ExternalURL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 %s", payload.ExternalURL))
+	}
+
+	return sb.String()
+}
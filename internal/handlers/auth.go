@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"rsc.io/qr"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// PairPhone handles requests to link the client using a phone-number pairing code
+func (h *Handler) PairPhone(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	var req models.PairPhoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	phoneNumber, appErr := h.validator.NormalizePhoneNumber(req.PhoneNumber)
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	code, err := h.waClient.PairWithPhone(r.Context(), phoneNumber)
+	if err != nil {
+		if stderrors.Is(err, app.ErrAlreadyPaired) {
+			h.writeAppError(w, errors.AlreadyPaired())
+			return
+		}
+		h.log.Error("Failed to generate pairing code", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+
+	h.writeJSON(w, &models.PairPhoneResponse{
+		Code:      code,
+		Timestamp: time.Now().Unix(),
+	}, http.StatusOK)
+}
+
+// GetQRCode handles requests for the QR code currently awaiting a scan, as JSON
+// (default) or as a raw PNG image with ?format=png
+func (h *Handler) GetQRCode(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	code, generatedAt, ok := h.waClient.CurrentQRCode()
+	if !ok {
+		h.writeAppError(w, errors.NotFound("No QR code is active (the client is already authenticated or authentication hasn't started)"))
+		return
+	}
+
+	qrCode, err := qr.Encode(code, qr.M)
+	if err != nil {
+		h.log.Error("Failed to encode QR code", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+	png := qrCode.PNG()
+
+	if r.URL.Query().Get("format") == "png" {
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(png); err != nil {
+			h.log.Debugf("Failed to write QR code image: %v", err)
+		}
+		return
+	}
+
+	h.writeJSON(w, &models.QRCodeResponse{
+		Code:        code,
+		Image:       "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+		GeneratedAt: generatedAt.Unix(),
+	}, http.StatusOK)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// ProviderBitbucket identifies Bitbucket Cloud webhooks
+const ProviderBitbucket WebhookProvider = "Bitbucket"
+
+// SetBitbucketConfig sets the Bitbucket webhook secret, recipient and priority
+func (h *Handler) SetBitbucketConfig(secret, recipient, priority string) {
+	h.bitbucketSecret = secret
+	h.bitbucketRecipient = recipient
+	h.bitbucketPriority = models.Priority(priority).OrDefault()
+}
+
+// BitbucketWebhook handles Bitbucket Cloud webhook requests
+func (h *Handler) BitbucketWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
+	config := WebhookConfig{
+		Provider:       ProviderBitbucket,
+		DeliveryHeader: "X-Request-UUID",
+		// Bitbucket doesn't sign payloads by default; a shared secret is passed in the URL instead
+		SecretQueryParam: "secret",
+		Secret:           h.bitbucketSecret,
+		Recipient:        h.bitbucketRecipient,
+		Priority:         h.bitbucketPriority,
+	}
+	h.webhookMutex.RUnlock()
+
+	parsePayload := func(body []byte) (WebhookPayload, error) {
+		var payload models.BitbucketWebhookPayload
+		err := json.Unmarshal(body, &payload)
+		return payload, err
+	}
+
+	h.handleWebhook(w, r, config, parsePayload)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// defaultBulkConcurrency is the default number of bulk sends in flight at once
+const defaultBulkConcurrency = 5
+
+// Bulk send result statuses
+const (
+	BulkStatusSent    = "sent"
+	BulkStatusFailed  = "failed"  // WhatsApp rejected the send
+	BulkStatusSkipped = "skipped" // Invalid or duplicate recipient, not sent
+)
+
+// SetBulkConcurrency sets how many messages /send/bulk sends at once
+func (h *Handler) SetBulkConcurrency(concurrency int) {
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	h.bulkConcurrency = concurrency
+}
+
+// SendBulkMessage handles requests to send the same message to many recipients.
+// Recipients are validated individually; invalid ones are reported and skipped,
+// and the rest are sent by a bounded pool of workers.
+func (h *Handler) SendBulkMessage(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	var req models.SendBulkMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	if appErr := h.validator.ValidateSendBulkMessageRequest(&req); appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	req.Message = h.validator.SanitizeMessage(req.Message)
+	req.Priority = req.Priority.OrDefault()
+
+	// Validate each recipient up front so only valid, distinct ones are dispatched
+	results := make([]models.BulkSendResult, len(req.To))
+	pending := make([]int, 0, len(req.To))
+	seen := make(map[string]bool, len(req.To))
+	for i, to := range req.To {
+		to = strings.TrimSpace(to)
+		results[i] = models.BulkSendResult{To: to, Status: BulkStatusSkipped}
+
+		single := models.SendMessageRequest{To: to, Message: req.Message, Priority: req.Priority, OmitFooter: req.OmitFooter}
+		if appErr := h.validator.ValidateSendMessageRequest(&single); appErr != nil {
+			results[i].Error = appErr.Message
+			continue
+		}
+		if seen[to] {
+			results[i].Error = "Duplicate recipient"
+			continue
+		}
+		seen[to] = true
+		pending = append(pending, i)
+	}
+
+	if len(pending) > 0 {
+		// Ensure client is connected and past its ready grace period
+		if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+			h.log.Error("Failed to connect client", err)
+			h.writeAppError(w, errors.ConnectionFailed(err))
+			return
+		}
+
+		h.sendBulk(r.Context(), req, results, pending)
+	}
+
+	response := &models.SendBulkMessageResponse{
+		Results:   results,
+		Priority:  req.Priority,
+		Timestamp: time.Now().Unix(),
+	}
+	for _, result := range results {
+		if result.Status == BulkStatusSent {
+			response.Sent++
+		} else {
+			response.Failed++
+		}
+	}
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// sendBulk sends the message to each pending recipient, filling in their results
+func (h *Handler) sendBulk(ctx context.Context, req models.SendBulkMessageRequest, results []models.BulkSendResult, pending []int) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for range min(h.bulkConcurrency, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = h.sendBulkOne(ctx, results[i].To, req)
+			}
+		}()
+	}
+
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// sendBulkOne sends the bulk message to a single recipient
+func (h *Handler) sendBulkOne(ctx context.Context, to string, req models.SendBulkMessageRequest) models.BulkSendResult {
+	result := models.BulkSendResult{To: to, Status: BulkStatusFailed}
+
+	// Queue the send behind the per-recipient cooldown and higher-priority sends
+	release, err := h.acquireSend(ctx, to, req.Priority)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer release()
+
+	opts := h.sendOptions(nil)
+	opts.OmitFooter = req.OmitFooter
+
+	messageID, err := h.waClient.SendTextWithOptions(ctx, to, req.Message, opts)
+	if err != nil {
+		h.log.Errorf("Failed to send bulk message to %s: %v", to, err)
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = BulkStatusSent
+	result.MessageID = messageID
+	return result
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// confirmCallbackTimeout bounds the POST to CONFIRM_CALLBACK_URL
+const confirmCallbackTimeout = 10 * time.Second
+
+// confirmationLinks issues signed, expiring links recipients open to confirm they
+// received a message. Tokens carry their own claims, so links keep working across
+// restarts; the issued map only tracks state for lookups.
+type confirmationLinks struct {
+	secret      []byte
+	baseURL     string
+	ttl         time.Duration
+	callbackURL string
+	client      *http.Client
+	log         *logger.Logger
+
+	mutex  sync.Mutex
+	issued map[string]*models.ConfirmationInfo // Confirmation ID -> state
+}
+
+// confirmationClaims is the signed content of a confirmation token
+type confirmationClaims struct {
+	ID        string `json:"id"`
+	To        string `json:"to"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// SetConfirmationLinks enables receipt confirmation links signed with secret, built on
+// baseURL and valid for ttl. Each first confirmation is POSTed to callbackURL when set.
+// An empty secret disables confirmation links.
+func (h *Handler) SetConfirmationLinks(secret, baseURL string, ttl time.Duration, callbackURL string) {
+	if secret == "" {
+		h.confirmations = nil
+		return
+	}
+	h.confirmations = &confirmationLinks{
+		secret:      []byte(secret),
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		ttl:         ttl,
+		callbackURL: callbackURL,
+		client:      &http.Client{Timeout: confirmCallbackTimeout},
+		log:         h.log,
+		issued:      make(map[string]*models.ConfirmationInfo),
+	}
+}
+
+// issue creates a confirmation for a recipient and returns its claims and link
+func (c *confirmationLinks) issue(to string) (confirmationClaims, string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return confirmationClaims{}, "", fmt.Errorf("failed to generate confirmation ID: %w", err)
+	}
+	claims := confirmationClaims{
+		ID:        hex.EncodeToString(id),
+		To:        to,
+		ExpiresAt: time.Now().Add(c.ttl).Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return confirmationClaims{}, "", fmt.Errorf("failed to encode confirmation token: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + c.sign(encoded)
+
+	return claims, c.baseURL + "/confirm/" + token, nil
+}
+
+// sign returns the base64url HMAC SHA256 signature of an encoded payload
+func (c *confirmationLinks) sign(encoded string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks a token's signature and expiry and returns its claims
+func (c *confirmationLinks) verify(token string) (confirmationClaims, bool) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(c.sign(encoded))) {
+		return confirmationClaims{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return confirmationClaims{}, false
+	}
+	var claims confirmationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ID == "" {
+		return confirmationClaims{}, false
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return confirmationClaims{}, false
+	}
+	return claims, true
+}
+
+// record tracks an issued confirmation once its message was sent
+func (c *confirmationLinks) record(claims confirmationClaims, messageID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Forget confirmations a TTL after they expired so the map stays bounded
+	cutoff := time.Now().Add(-c.ttl).Unix()
+	for id, info := range c.issued {
+		if info.ExpiresAt < cutoff {
+			delete(c.issued, id)
+		}
+	}
+
+	c.issued[claims.ID] = &models.ConfirmationInfo{
+		ID:        claims.ID,
+		To:        claims.To,
+		MessageID: messageID,
+		Status:    models.ConfirmationPending,
+		ExpiresAt: claims.ExpiresAt,
+	}
+}
+
+// confirm marks a confirmation as confirmed and reports whether this was the first time
+func (c *confirmationLinks) confirm(claims confirmationClaims) (models.ConfirmationInfo, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	info, ok := c.issued[claims.ID]
+	if !ok {
+		// Issued before a restart; the signed claims are enough to record it
+		info = &models.ConfirmationInfo{ID: claims.ID, To: claims.To, ExpiresAt: claims.ExpiresAt}
+		c.issued[claims.ID] = info
+	}
+	if info.Status == models.ConfirmationConfirmed {
+		return *info, false
+	}
+
+	info.Status = models.ConfirmationConfirmed
+	info.ConfirmedAt = time.Now().Unix()
+	return *info, true
+}
+
+// lookup returns the state of a confirmation
+func (c *confirmationLinks) lookup(id string) (models.ConfirmationInfo, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	info, ok := c.issued[id]
+	if !ok {
+		return models.ConfirmationInfo{}, false
+	}
+	return *info, true
+}
+
+// notify POSTs a confirmation to the callback URL, if one is configured
+func (c *confirmationLinks) notify(info models.ConfirmationInfo) {
+	if c.callbackURL == "" {
+		return
+	}
+
+	body, err := json.Marshal(info)
+	if err != nil {
+		c.log.Errorf("Failed to encode confirmation callback: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), confirmCallbackTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.callbackURL, bytes.NewReader(body))
+	if err != nil {
+		c.log.Errorf("Failed to create confirmation callback request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.log.Warnf("Confirmation callback for %s failed: %v", info.ID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		c.log.Warnf("Confirmation callback for %s returned %s", info.ID, resp.Status)
+	}
+}
+
+// ConfirmReceipt handles a recipient opening a confirmation link. It needs no API key;
+// the signed token authenticates the request.
+func (h *Handler) ConfirmReceipt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use GET"))
+		return
+	}
+
+	confirmations := h.confirmations
+	if confirmations == nil {
+		h.writeAppError(w, errors.NotFound("Confirmation links are not enabled"))
+		return
+	}
+
+	claims, ok := confirmations.verify(r.PathValue("token"))
+	if !ok {
+		h.writeAppError(w, errors.NotFound("Invalid or expired confirmation link"))
+		return
+	}
+
+	info, first := confirmations.confirm(claims)
+	if first {
+		h.log.Infof("Receipt confirmed by %s (confirmation %s)", info.To, info.ID)
+		go confirmations.notify(info)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "Thanks, your receipt is confirmed.")
+}
+
+// GetConfirmation handles requests for the state of a confirmation link
+func (h *Handler) GetConfirmation(w http.ResponseWriter, r *http.Request) {
+	if h.confirmations == nil {
+		h.writeAppError(w, errors.NotFound("Confirmation links are not enabled"))
+		return
+	}
+
+	id := r.PathValue("id")
+	info, ok := h.confirmations.lookup(id)
+	if !ok {
+		h.writeAppError(w, errors.NotFound("Confirmation not found: "+id))
+		return
+	}
+	h.writeJSON(w, info, http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// recipientCooldown enforces a minimum interval between messages to the same recipient
+type recipientCooldown struct {
+	interval time.Duration
+	nextSend map[string]time.Time // Earliest time the next message to a JID may be sent
+	mutex    sync.Mutex
+}
+
+// newRecipientCooldown creates a cooldown tracker; an interval of zero disables it
+func newRecipientCooldown(interval time.Duration) *recipientCooldown {
+	return &recipientCooldown{
+		interval: interval,
+		nextSend: make(map[string]time.Time),
+	}
+}
+
+// reserve claims the next send slot for a recipient and returns when it starts
+func (c *recipientCooldown) reserve(jid string) time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	slot := now
+	if next, ok := c.nextSend[jid]; ok && next.After(now) {
+		slot = next
+	}
+
+	// Drop recipients whose cooldown has passed so the map stays bounded by the number of active recipients
+	for j, next := range c.nextSend {
+		if !next.After(now) {
+			delete(c.nextSend, j)
+		}
+	}
+
+	c.nextSend[jid] = slot.Add(c.interval)
+
+	return slot
+}
+
+// release gives back a reserved slot that won't be used. Only the most recent
+// reservation can be rolled back; later ones already wait for their own slots.
+func (c *recipientCooldown) release(jid string, slot time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.nextSend[jid].Equal(slot.Add(c.interval)) {
+		c.nextSend[jid] = slot
+	}
+}
+
+// Wait blocks until a message may be sent to the recipient or the context is done
+func (c *recipientCooldown) Wait(ctx context.Context, jid string) error {
+	if c.interval <= 0 {
+		return nil
+	}
+
+	slot := c.reserve(jid)
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// The send won't happen, so don't hold later sends back for it
+		c.release(jid, slot)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// ProviderCustom identifies deliveries to the generic templated webhook
+const ProviderCustom WebhookProvider = "Custom"
+
+// SetCustomWebhookConfig sets the message template, signature verification and default
+// recipient of the custom webhook. The template is validated by config.Validate, so an
+// invalid template leaves the endpoint disabled.
+func (h *Handler) SetCustomWebhookConfig(tmpl, secret string, signatureHeader SignatureHeader, recipient, priority string) {
+	h.customTemplate = nil
+	if tmpl != "" {
+		parsed, err := template.New("custom").Parse(tmpl)
+		if err != nil {
+			h.log.Warnf("Disabling custom webhook with invalid template: %v", err)
+		} else {
+			h.customTemplate = parsed
+		}
+	}
+
+	h.customSecret = secret
+	h.customSignatureHeader = signatureHeader
+	h.customRecipient = recipient
+	h.customPriority = models.Priority(priority).OrDefault()
+}
+
+// SetMessageTemplate sets the Go template push notifications from a provider are rendered
+// with, executed against the WebhookPayload with models.MessageTemplateFuncs available.
+// An empty template restores the built-in format; so does an invalid one, which
+// config.Validate rejects up front.
+func (h *Handler) SetMessageTemplate(provider WebhookProvider, tmpl string) {
+	if h.messageTemplates == nil {
+		h.messageTemplates = make(map[WebhookProvider]*template.Template)
+	}
+	delete(h.messageTemplates, provider)
+	if tmpl == "" {
+		return
+	}
+
+	parsed, err := template.New(string(provider)).Funcs(models.MessageTemplateFuncs).Parse(tmpl)
+	if err != nil {
+		h.log.Warnf("Ignoring invalid %s message template: %v", provider, err)
+		return
+	}
+	h.messageTemplates[provider] = parsed
+}
+
+// CustomWebhook handles deliveries of arbitrary JSON payloads, rendered with CUSTOM_WEBHOOK_TEMPLATE
+func (h *Handler) CustomWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
+	tmpl := h.customTemplate
+	config := WebhookConfig{
+		Provider:         ProviderCustom,
+		SignatureHeaders: []SignatureHeader{h.customSignatureHeader},
+		Secret:           h.customSecret,
+		Recipient:        h.customRecipient,
+		Priority:         h.customPriority,
+	}
+	h.webhookMutex.RUnlock()
+
+	if tmpl == nil {
+		h.writeAppError(w, errors.NotFound("Custom webhook is not configured"))
+		return
+	}
+	parsePayload := func(body []byte) (WebhookPayload, error) {
+		var payload models.CustomWebhookPayload
+		if err := json.Unmarshal(body, &payload.Fields); err != nil {
+			return payload, err
+		}
+
+		payload.Recipient = config.Recipient
+		if recipient, ok := payload.Fields["recipient"].(string); ok && recipient != "" {
+			jid, appErr := h.validator.NormalizeJID(recipient)
+			if appErr != nil {
+				return payload, fmt.Errorf("invalid recipient: %s", recipient)
+			}
+			payload.Recipient = jid
+		}
+		if payload.Recipient == "" {
+			return payload, fmt.Errorf("'recipient' is required")
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, payload.Fields); err != nil {
+			return payload, fmt.Errorf("failed to render template: %w", err)
+		}
+		payload.Message = strings.TrimSpace(sb.String())
+		return payload, nil
+	}
+
+	h.handleWebhook(w, r, config, parsePayload)
+}
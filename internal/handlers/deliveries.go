@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// deliveryTracker remembers recently seen webhook delivery IDs so repeated
+// deliveries of the same event can be short-circuited
+type deliveryTracker struct {
+	window time.Duration
+	seen   map[string]time.Time // Delivery key -> time it was first seen
+	mutex  sync.Mutex
+}
+
+// newDeliveryTracker creates a delivery tracker; a window of zero disables it
+func newDeliveryTracker(window time.Duration) *deliveryTracker {
+	return &deliveryTracker{
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// seenRecently records a delivery and reports whether it was already seen within the window.
+// The delivery counts as seen while it is processed; call forget if processing fails.
+func (t *deliveryTracker) seenRecently(key string) bool {
+	if t.window <= 0 || key == "" {
+		return false
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	if firstSeen, ok := t.seen[key]; ok && now.Sub(firstSeen) < t.window {
+		return true
+	}
+
+	// Drop expired entries so the map stays bounded by the delivery rate
+	for k, firstSeen := range t.seen {
+		if now.Sub(firstSeen) >= t.window {
+			delete(t.seen, k)
+		}
+	}
+
+	t.seen[key] = now
+	return false
+}
+
+// forget removes a delivery so a retry of it is processed again
+func (t *deliveryTracker) forget(key string) {
+	if t.window <= 0 || key == "" {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.seen, key)
+}
+
+// contentDeduper remembers a hash of the last notification sent to each recipient so
+// identical content from separate deliveries (e.g. a misconfigured CI re-sending the
+// same push) can be suppressed
+type contentDeduper struct {
+	window time.Duration
+	last   map[string]sentContent // Recipient -> last notification sent to it
+	mutex  sync.Mutex
+}
+
+// sentContent is the hash of a sent notification and when it was sent
+type sentContent struct {
+	hash   [sha256.Size]byte
+	sentAt time.Time
+}
+
+// newContentDeduper creates a content deduper; a window of zero disables it
+func newContentDeduper(window time.Duration) *contentDeduper {
+	return &contentDeduper{
+		window: window,
+		last:   make(map[string]sentContent),
+	}
+}
+
+// isDuplicate reports whether message is identical to the last one sent to the
+// recipient within the window
+func (d *contentDeduper) isDuplicate(recipient, message string) bool {
+	if d.window <= 0 {
+		return false
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	last, ok := d.last[recipient]
+	return ok && time.Since(last.sentAt) < d.window && last.hash == sha256.Sum256([]byte(message))
+}
+
+// record remembers message as the last one sent to the recipient
+func (d *contentDeduper) record(recipient, message string) {
+	if d.window <= 0 {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Drop expired entries so the map stays bounded by the number of active recipients
+	now := time.Now()
+	for r, last := range d.last {
+		if now.Sub(last.sentAt) >= d.window {
+			delete(d.last, r)
+		}
+	}
+
+	d.last[recipient] = sentContent{hash: sha256.Sum256([]byte(message)), sentAt: now}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// digestMaxEvents is the number of buffered events that triggers an early flush
+const digestMaxEvents = 20
+
+// digestEvent is a formatted webhook notification waiting to be sent in a digest
+type digestEvent struct {
+	repository string
+	message    string
+	config     WebhookConfig
+}
+
+// webhookDigest buffers a provider's notifications and sends them as one combined
+// message per recipient every interval, or sooner when the buffer fills up
+type webhookDigest struct {
+	provider WebhookProvider
+	interval time.Duration
+	send     func(ctx context.Context, recipient, message string, config WebhookConfig) (string, error)
+	log      func(format string, args ...interface{})
+
+	mutex   sync.Mutex
+	pending map[string][]digestEvent // Recipient -> buffered events, in arrival order
+	order   []string                 // Recipients in the order they were first buffered
+	count   int
+
+	flushMutex sync.Mutex // Serializes flushes so digests go out in order
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// newWebhookDigest creates a digest and starts its flush timer
+func newWebhookDigest(provider WebhookProvider, interval time.Duration, send func(context.Context, string, string, WebhookConfig) (string, error), log func(string, ...interface{})) *webhookDigest {
+	d := &webhookDigest{
+		provider: provider,
+		interval: interval,
+		send:     send,
+		log:      log,
+		pending:  make(map[string][]digestEvent),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// run flushes the digest every interval until stopped
+func (d *webhookDigest) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			d.flush(context.Background())
+		}
+	}
+}
+
+// add buffers an event for a recipient, flushing in the background once the buffer is large
+func (d *webhookDigest) add(recipient, repository, message string, config WebhookConfig) {
+	d.mutex.Lock()
+	if _, ok := d.pending[recipient]; !ok {
+		d.order = append(d.order, recipient)
+	}
+	d.pending[recipient] = append(d.pending[recipient], digestEvent{repository: repository, message: message, config: config})
+	d.count++
+	full := d.count >= digestMaxEvents
+	d.mutex.Unlock()
+
+	if full {
+		go d.flush(context.Background())
+	}
+}
+
+// flush sends one combined message per recipient for everything buffered so far
+func (d *webhookDigest) flush(ctx context.Context) {
+	d.flushMutex.Lock()
+	defer d.flushMutex.Unlock()
+
+	d.mutex.Lock()
+	pending, order := d.pending, d.order
+	d.pending, d.order, d.count = make(map[string][]digestEvent), nil, 0
+	d.mutex.Unlock()
+
+	for _, recipient := range order {
+		events := pending[recipient]
+		message := formatDigest(d.provider, events)
+
+		// The most recent event's settings (e.g. priority) apply to the digest
+		if _, err := d.send(ctx, recipient, message, events[len(events)-1].config); err != nil {
+			d.log("Failed to send %s digest of %d event(s) to %s: %v", d.provider, len(events), recipient, err)
+		}
+	}
+}
+
+// Close stops the flush timer and sends whatever is still buffered
+func (d *webhookDigest) Close(ctx context.Context) {
+	close(d.stop)
+	<-d.done
+	d.flush(ctx)
+}
+
+// formatDigest combines buffered notifications into one message, grouped by repository
+func formatDigest(provider WebhookProvider, events []digestEvent) string {
+	var repositories []string
+	byRepository := make(map[string][]string)
+	for _, event := range events {
+		if _, ok := byRepository[event.repository]; !ok {
+			repositories = append(repositories, event.repository)
+		}
+		byRepository[event.repository] = append(byRepository[event.repository], event.message)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📬 *%s digest*: %d event(s) in %d repository(ies)\n", provider, len(events), len(repositories)))
+	for _, repository := range repositories {
+		sb.WriteString(fmt.Sprintf("\n━━━━━━━━━━\n📁 *%s*\n\n", repository))
+		sb.WriteString(strings.Join(byRepository[repository], "\n\n"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// SetDigestInterval enables digest mode for a provider: notifications are buffered
+// and sent as one combined message per interval. Zero keeps per-event messages.
+func (h *Handler) SetDigestInterval(provider WebhookProvider, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	h.digests[provider] = newWebhookDigest(provider, interval, h.sendDigest, h.log.Errorf)
+}
+
+// sendDigest sends a combined digest, reconnecting first if needed since it runs off a timer
+func (h *Handler) sendDigest(ctx context.Context, recipient, message string, config WebhookConfig) (string, error) {
+	if err := h.waClient.EnsureConnected(ctx); err != nil {
+		return "", err
+	}
+	return h.sendWebhookNotification(ctx, recipient, message, config)
+}
+
+// FlushDigests stops all digest timers and sends any buffered notifications
+func (h *Handler) FlushDigests(ctx context.Context) {
+	for _, digest := range h.digests {
+		digest.Close(ctx)
+	}
+}
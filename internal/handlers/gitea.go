@@ -7,15 +7,35 @@ import (
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
+// defaultGiteaSignatureHeader carries Gitea's legacy hex-only signature
+const defaultGiteaSignatureHeader = "X-Gitea-Signature"
+
+// SetGiteaPayloadSecret sets whether the secret field in the Gitea payload is accepted
+// in place of a signature header
+func (h *Handler) SetGiteaPayloadSecret(enabled bool) {
+	h.giteaPayloadSecret = enabled
+}
+
 // GiteaWebhook handles Gitea webhook requests
 func (h *Handler) GiteaWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
 	config := WebhookConfig{
-		Provider:        ProviderGitea,
-		SignatureHeader: "X-Gitea-Signature",
-		Secret:          h.giteaSecret,
-		Recipient:       h.giteaRecipient,
-		SignaturePrefix: "", // Gitea doesn't use a prefix
+		Provider:       ProviderGitea,
+		DeliveryHeader: "X-Gitea-Delivery",
+		SignatureHeaders: []SignatureHeader{
+			{Name: h.giteaSignatureHeader, Prefix: ""},       // Legacy hex-only signature
+			{Name: "X-Hub-Signature-256", Prefix: "sha256="}, // GitHub-compatible signature
+			{Name: "X-Gogs-Signature", Prefix: ""},           // Gogs-compatible copy of X-Gitea-Signature
+		},
+		Secret:    h.giteaSecret,
+		Recipient: h.giteaRecipient,
+		Priority:  h.giteaPriority,
+	}
+	if h.giteaPayloadSecret {
+		// Older Gitea versions only send the secret in the payload
+		config.PayloadSecret = "secret"
 	}
+	h.webhookMutex.RUnlock()
 
 	parsePayload := func(body []byte) (WebhookPayload, error) {
 		var payload models.GiteaWebhookPayload
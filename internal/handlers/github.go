@@ -2,26 +2,127 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
+// defaultGitHubSignatureHeader carries GitHub's HMAC SHA256 signature
+const defaultGitHubSignatureHeader = "X-Hub-Signature-256"
+
+// SetSignatureHeaders sets the headers the Gitea and GitHub webhooks read their primary
+// signature from, for proxies that rename headers. Empty names restore the defaults.
+func (h *Handler) SetSignatureHeaders(giteaHeader, githubHeader string) {
+	if giteaHeader == "" {
+		giteaHeader = defaultGiteaSignatureHeader
+	}
+	if githubHeader == "" {
+		githubHeader = defaultGitHubSignatureHeader
+	}
+	h.giteaSignatureHeader = giteaHeader
+	h.githubSignatureHeader = githubHeader
+}
+
 // GitHubWebhook handles GitHub webhook requests
 func (h *Handler) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
 	config := WebhookConfig{
-		Provider:        ProviderGitHub,
-		SignatureHeader: "X-Hub-Signature-256",
-		Secret:          h.githubSecret,
-		Recipient:       h.githubRecipient,
-		SignaturePrefix: "sha256=", // GitHub uses "sha256=" prefix
+		Provider:       ProviderGitHub,
+		DeliveryHeader: "X-GitHub-Delivery",
+		SignatureHeaders: []SignatureHeader{
+			{Name: h.githubSignatureHeader, Prefix: "sha256="}, // GitHub uses "sha256=" prefix
+		},
+		Secret:    h.githubSecret,
+		Recipient: h.githubRecipient,
+		Priority:  h.githubPriority,
 	}
+	h.webhookMutex.RUnlock()
 
+	// The payload shape depends on the event type; deliveries without the header are treated as pushes
+	event := r.Header.Get("X-GitHub-Event")
 	parsePayload := func(body []byte) (WebhookPayload, error) {
-		var payload models.GitHubWebhookPayload
-		err := json.Unmarshal(body, &payload)
-		return payload, err
+		switch event {
+		case "", models.GitHubEventPush:
+			var payload models.GitHubWebhookPayload
+			err := json.Unmarshal(body, &payload)
+			return payload, err
+		case models.GitHubEventPullRequest:
+			var payload models.GitHubPullRequestPayload
+			err := json.Unmarshal(body, &payload)
+			return payload, err
+		case models.GitHubEventIssues:
+			var payload models.GitHubIssuesPayload
+			err := json.Unmarshal(body, &payload)
+			return payload, err
+		default:
+			payload := models.GitHubIgnoredEvent{Event: event}
+			err := json.Unmarshal(body, &payload)
+			return payload, err
+		}
 	}
 
 	h.handleWebhook(w, r, config, parsePayload)
 }
+
+// formatGitHubPullRequestMessage constructs a WhatsApp message for an opened or closed pull request
+func formatGitHubPullRequestMessage(payload models.GitHubPullRequestPayload) string {
+	pr := payload.PullRequest
+
+	emoji, action := "🔀", payload.Action
+	switch {
+	case payload.Action == "closed" && pr.Merged:
+		emoji, action = "🟣", "merged"
+	case payload.Action == "closed":
+		emoji = "⛔"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s Pull request %s in *%s*\n", emoji, action, payload.GetRepositoryName()))
+	sb.WriteString(fmt.Sprintf("\n*#%d %s*\n", pr.Number, pr.Title))
+	sb.WriteString("\n```")
+	sb.WriteString(fmt.Sprintf("👤 Author : %s\n", pr.User.Login))
+	if action == "merged" && pr.MergedBy != nil {
+		sb.WriteString(fmt.Sprintf("✅ Merger : %s\n", pr.MergedBy.Login))
+	} else if action != "opened" {
+		sb.WriteString(fmt.Sprintf("✋ Closer : %s\n", payload.Sender.Login))
+	}
+	sb.WriteString(fmt.Sprintf("🌿 Branch : %s → %s\n", pr.Head.Ref, pr.Base.Ref))
+	sb.WriteString("```\n")
+
+	if pr.HTMLURL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 %s", pr.HTMLURL))
+	}
+
+	return sb.String()
+}
+
+// formatGitHubIssueMessage constructs a WhatsApp message for an opened or closed issue
+func formatGitHubIssueMessage(payload models.GitHubIssuesPayload) string {
+	issue := payload.Issue
+
+	emoji := "🐛"
+	if payload.Action == "closed" {
+		emoji = "✅"
+		if issue.StateReason == "not_planned" {
+			emoji = "🚫"
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s Issue %s in *%s*\n", emoji, payload.Action, payload.GetRepositoryName()))
+	sb.WriteString(fmt.Sprintf("\n*#%d %s*\n", issue.Number, issue.Title))
+	sb.WriteString("\n```")
+	sb.WriteString(fmt.Sprintf("👤 Author : %s\n", issue.User.Login))
+	if payload.Action == "closed" {
+		sb.WriteString(fmt.Sprintf("✋ Closer : %s\n", payload.Sender.Login))
+	}
+	sb.WriteString("```\n")
+
+	if issue.HTMLURL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 %s", issue.HTMLURL))
+	}
+
+	return sb.String()
+}
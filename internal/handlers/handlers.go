@@ -1,31 +1,280 @@
 package handlers
 
 import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"regexp"
+	"sync"
+	"text/template"
+	"time"
+
 	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/validation"
 )
 
 // Handler holds dependencies for HTTP handlers
 type Handler struct {
-	waClient        *app.WhatsAppClient
-	log             *logger.Logger
-	validator       *validation.Validator
+	waClient  *app.WhatsAppClient
+	health    healthSource // The WhatsApp client; separate so the health check can be tested without one
+	log       *logger.Logger
+	validator *validation.Validator
+
+	// Guards the webhook secrets, recipients, priorities, custom template, keyword
+	// routes and raw API keys below, which can be replaced at runtime by Reload
+	webhookMutex sync.RWMutex
+
 	giteaSecret     string
 	giteaRecipient  string
 	githubSecret    string
 	githubRecipient string
+	giteaPriority   models.Priority
+	githubPriority  models.Priority
+
+	giteaPayloadSecret bool
+
+	// Headers carrying each provider's primary signature, renamed by some proxies
+	giteaSignatureHeader  string
+	githubSignatureHeader string
+
+	bitbucketSecret    string
+	bitbucketRecipient string
+	bitbucketPriority  models.Priority
+
+	jenkinsToken     string
+	jenkinsRecipient string
+	jenkinsPriority  models.Priority
+
+	alertmanagerToken     string
+	alertmanagerRecipient string
+	alertmanagerPriority  models.Priority
+
+	customTemplate        *template.Template // nil disables the custom webhook
+	customSecret          string
+	customSignatureHeader SignatureHeader
+	customRecipient       string
+	customPriority        models.Priority
+
+	messageTemplates map[WebhookProvider]*template.Template // Push notification templates; missing providers use the built-in format
+
+	debugMode      bool
+	cooldown       *recipientCooldown
+	markForwarded  bool
+	webhookMaxAge  time.Duration
+	strictSigs     bool // Reject deliveries with unexpected or unverified extra signature headers
+	showMerges     bool
+	pusherSource   string // Identity shown as the pusher (a models.PusherSource value)
+	fileChanges    string // How changed files are shown (a models.FileChanges value)
+	deliveries     *deliveryTracker
+	contentDedupe  *contentDeduper
+	webhookHistory *webhookHistory
+	digests        map[WebhookProvider]*webhookDigest
+	maxMediaSize   int64
+	jsonBufferSize int
+	sendStatus     int
+	severityEmoji  map[string]string
+	repoIcons      map[string]string // Repository (lowercase) to push notification emoji
+	branchDisplay  *regexp.Regexp    // Extracts the displayed branch name; nil shows it in full
+	branchFilter   []string          // Glob patterns of branches that trigger notifications; empty allows all
+	rawAPIKeys     []string          // API keys allowed to skip message sanitization
+	sendQueue      *sendQueue
+	scheduler      *messageScheduler
+
+	bulkConcurrency int // Messages /send/bulk sends at once
+
+	confirmations *confirmationLinks // Receipt confirmation links; nil when disabled
+
+	reloadHooks []func(*config.Config) // Called with each reloaded configuration
+
+	degradedQueueAge time.Duration
+	healthProbe      *healthProbe
+
+	// Webhook keyword routing
+	keywordRoutes           []keywordRoute
+	replaceDefaultRecipient bool
+	forcePushRecipient      string // Also notified of force pushes (empty disables)
 }
 
 // New creates a new handler instance
 func New(waClient *app.WhatsAppClient, log *logger.Logger, giteaSecret, giteaRecipient, githubSecret, githubRecipient string) *Handler {
 	return &Handler{
 		waClient:        waClient,
+		health:          waClient,
 		log:             log,
 		validator:       validation.New(),
 		giteaSecret:     giteaSecret,
 		giteaRecipient:  giteaRecipient,
 		githubSecret:    githubSecret,
 		githubRecipient: githubRecipient,
+		giteaPriority:   models.PriorityNormal,
+		githubPriority:  models.PriorityNormal,
+
+		giteaSignatureHeader:  defaultGiteaSignatureHeader,
+		githubSignatureHeader: defaultGitHubSignatureHeader,
+
+		bitbucketPriority: models.PriorityNormal,
+		jenkinsPriority:   models.PriorityNormal,
+		customPriority:    models.PriorityNormal,
+
+		alertmanagerPriority: models.PriorityNormal,
+
+		cooldown:       newRecipientCooldown(0),
+		deliveries:     newDeliveryTracker(time.Minute),
+		contentDedupe:  newContentDeduper(0),
+		webhookHistory: newWebhookHistory(20),
+		digests:        make(map[WebhookProvider]*webhookDigest),
+		maxMediaSize:   defaultMaxMediaSize,
+		jsonBufferSize: defaultJSONBufferSize,
+		sendStatus:     http.StatusAccepted,
+		severityEmoji:  defaultSeverityEmoji,
+		sendQueue:      newSendQueue(),
+		scheduler:      newMessageScheduler(),
+		showMerges:     true,
+		pusherSource:   models.PusherSourceCommitter,
+		fileChanges:    models.FileChangesList,
+
+		bulkConcurrency: defaultBulkConcurrency,
+
+		degradedQueueAge: defaultDegradedQueueAge,
+	}
+}
+
+// SetDebugMode enables ?debug=true responses for unauthenticated requests
+func (h *Handler) SetDebugMode(enabled bool) {
+	h.debugMode = enabled
+}
+
+// SetSendSuccessStatus sets the HTTP status returned when a send succeeds
+func (h *Handler) SetSendSuccessStatus(status int) {
+	if status != http.StatusOK && status != http.StatusAccepted {
+		status = http.StatusAccepted
+	}
+	h.sendStatus = status
+}
+
+// SetWebhookPriorities sets the delivery priority used for webhook notifications
+func (h *Handler) SetWebhookPriorities(giteaPriority, githubPriority string) {
+	h.giteaPriority = models.Priority(giteaPriority).OrDefault()
+	h.githubPriority = models.Priority(githubPriority).OrDefault()
+}
+
+// SetStripJIDDeviceSuffix controls whether device/agent suffixes are stripped from JIDs
+func (h *Handler) SetStripJIDDeviceSuffix(enabled bool) {
+	h.validator.SetStripDeviceSuffix(enabled)
+}
+
+// SetMessageFooter sets the footer appended to text messages, so request validation
+// accounts for its length
+func (h *Handler) SetMessageFooter(footer string) {
+	h.validator.SetMessageFooter(footer)
+}
+
+// SetRecipientCooldown sets the minimum interval between messages to the same recipient
+func (h *Handler) SetRecipientCooldown(interval time.Duration) {
+	h.cooldown = newRecipientCooldown(interval)
+}
+
+// SetWebhookMaxAge sets the maximum accepted age of webhook deliveries (0 disables the check)
+func (h *Handler) SetWebhookMaxAge(maxAge time.Duration) {
+	h.webhookMaxAge = maxAge
+}
+
+// SetStrictSignatureHeaders sets whether webhook deliveries carrying a signature header
+// another provider uses, or an extra accepted signature that doesn't verify, are rejected
+func (h *Handler) SetStrictSignatureHeaders(enabled bool) {
+	h.strictSigs = enabled
+}
+
+// SetShowMergeCommits sets whether merge commits are listed in push notifications
+func (h *Handler) SetShowMergeCommits(enabled bool) {
+	h.showMerges = enabled
+}
+
+// SetPusherSource sets which identity push notifications show as the pusher:
+// the first commit's committer or author, or the account that pushed
+func (h *Handler) SetPusherSource(source string) {
+	if source == "" {
+		source = models.PusherSourceCommitter
+	}
+	h.pusherSource = source
+}
+
+// SetFileChangesMode sets whether push notifications list changed files
+// or only show the added, modified and removed totals
+func (h *Handler) SetFileChangesMode(mode string) {
+	if mode == "" {
+		mode = models.FileChangesList
+	}
+	h.fileChanges = mode
+}
+
+// SetRawAPIKeys sets the API keys allowed to send messages verbatim, skipping sanitization
+func (h *Handler) SetRawAPIKeys(keys []string) {
+	h.webhookMutex.Lock()
+	defer h.webhookMutex.Unlock()
+
+	h.rawAPIKeys = keys
+}
+
+// canSendRaw reports whether the request's API key may skip message sanitization.
+// The key has already been validated by the API key middleware.
+func (h *Handler) canSendRaw(r *http.Request) bool {
+	apiKey := r.Header.Get("X-API-Key")
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get("api_key")
+	}
+	if apiKey == "" {
+		return false
+	}
+
+	h.webhookMutex.RLock()
+	defer h.webhookMutex.RUnlock()
+
+	for _, rawKey := range h.rawAPIKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(rawKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMarkForwarded sets whether messages are marked as "Forwarded many times" by default
+func (h *Handler) SetMarkForwarded(enabled bool) {
+	h.markForwarded = enabled
+}
+
+// sendOptions builds send options, applying a per-request forwarded override if given
+func (h *Handler) sendOptions(forwarded *bool) app.SendOptions {
+	opts := app.SendOptions{Forwarded: h.markForwarded}
+	if forwarded != nil {
+		opts.Forwarded = *forwarded
+	}
+	return opts
+}
+
+// acquireSend queues a send to jid. Low-priority sends are rejected with errSendQueueFull
+// when the queue is full; others wait out the recipient's cooldown, which urgent messages
+// skip, and then for a send slot, which goes to urgent messages first. The returned
+// function must be called once the send is done.
+func (h *Handler) acquireSend(ctx context.Context, jid string, priority models.Priority) (func(), error) {
+	entry, err := h.sendQueue.enter(priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if priority != models.PriorityUrgent {
+		if err := h.cooldown.Wait(ctx, jid); err != nil {
+			entry.release()
+			return nil, err
+		}
+	}
+
+	if err := entry.acquire(ctx); err != nil {
+		entry.release()
+		return nil, err
 	}
+	return entry.release, nil
 }
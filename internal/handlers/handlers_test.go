@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
+)
+
+const (
+	testGiteaSecret  = "gitea-test-secret"
+	testGitHubSecret = "github-test-secret"
+	testRecipient    = "1234567890@s.whatsapp.net"
+)
+
+// newTestHandler creates a handler with webhook secrets and recipients set and
+// logging disabled. waClient may be nil for code paths that don't reach WhatsApp.
+func newTestHandler(waClient *app.WhatsAppClient) *Handler {
+	log := logger.New("disabled", "json", "", logger.Rotation{})
+	return New(waClient, log, testGiteaSecret, testRecipient, testGitHubSecret, testRecipient)
+}
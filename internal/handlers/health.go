@@ -1,33 +1,176 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
+// Health status values
+const (
+	HealthOK        = "ok"        // Connected and sending normally
+	HealthDegraded  = "degraded"  // Connected, but the last send failed or the queue is backing up
+	HealthUnhealthy = "unhealthy" // Not connected to WhatsApp
+)
+
+// healthSource is the connection state the health check reports on
+type healthSource interface {
+	GetConnectionStatus() map[string]interface{}
+	LastSendResult() (time.Time, error)
+	ReconnectExhausted() (time.Time, bool)
+}
+
+// defaultDegradedQueueAge is how long a send may wait before health reports degraded
+const defaultDegradedQueueAge = time.Minute
+
+// SetDegradedQueueAge sets how long the oldest queued send may wait before health reports degraded
+func (h *Handler) SetDegradedQueueAge(age time.Duration) {
+	if age <= 0 {
+		age = defaultDegradedQueueAge
+	}
+	h.degradedQueueAge = age
+}
+
 // HealthCheck handles health check requests
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	connectionStatus := h.waClient.GetConnectionStatus()
+	connectionStatus := h.health.GetConnectionStatus()
+
+	// Default to disconnected if the status is missing or not a bool
+	// (e.g. during early startup before the store is ready)
+	connected, _ := connectionStatus["connected"].(bool)
+
+	queueStats := h.sendQueue.Stats()
+	lastSendTime, lastSendErr := h.health.LastSendResult()
+	exhaustedAt, exhausted := h.health.ReconnectExhausted()
 
 	response := &models.HealthResponse{
-		Status:    "ok",
-		Connected: connectionStatus["connected"].(bool),
+		Status:    h.healthStatus(connected, lastSendErr, queueStats),
+		Connected: connected,
 		Timestamp: time.Now().Unix(),
+
+		ReconnectExhausted: exhausted,
 	}
 
 	// Add detailed connection info if requested
 	if r.URL.Query().Get("detailed") == "true" {
-		// Add connection status details to response
-		h.writeJSON(w, map[string]interface{}{
+		detailed := map[string]interface{}{
 			"status":            response.Status,
 			"connected":         response.Connected,
 			"timestamp":         response.Timestamp,
 			"connection_status": connectionStatus,
-		}, http.StatusOK)
+			"queue_depth":       queueStats.Depth,
+			"oldest_queued_age": queueStats.OldestAge.Seconds(),
+		}
+		if lastSendErr != nil {
+			detailed["last_send_error"] = lastSendErr.Error()
+			detailed["last_send_error_at"] = lastSendTime.Unix()
+		}
+		if exhausted {
+			detailed["reconnect_exhausted"] = true
+			detailed["reconnect_exhausted_at"] = exhaustedAt.Unix()
+		}
+
+		// Add connection status and outbound queue details to response
+		h.writeJSON(w, detailed, http.StatusOK)
 		return
 	}
 
 	h.writeJSON(w, response, http.StatusOK)
 }
+
+// healthStatus classifies the service as ok, degraded or unhealthy
+func (h *Handler) healthStatus(connected bool, lastSendErr error, queueStats QueueStats) string {
+	if !connected {
+		return HealthUnhealthy
+	}
+	if lastSendErr != nil || queueStats.OldestAge > h.degradedQueueAge {
+		return HealthDegraded
+	}
+	return HealthOK
+}
+
+// healthProbe checks that the connection can query WhatsApp's servers by looking
+// up a known JID, caching the outcome so frequent probes don't spam WhatsApp
+type healthProbe struct {
+	jid string
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+}
+
+// SetHealthProbe configures the JID /readyz looks up to verify the connection end to end,
+// caching each result for ttl. An empty JID disables the probe.
+func (h *Handler) SetHealthProbe(jid string, ttl time.Duration) {
+	if jid == "" {
+		h.healthProbe = nil
+		return
+	}
+	h.healthProbe = &healthProbe{jid: jid, ttl: ttl}
+}
+
+// check returns the cached probe result, refreshing it once the TTL has passed
+func (p *healthProbe) check(ctx context.Context, h *Handler) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.checkedAt.IsZero() && time.Since(p.checkedAt) < p.ttl {
+		return p.lastErr
+	}
+
+	number := strings.SplitN(p.jid, "@", 2)[0]
+	statuses, err := h.waClient.IsOnWhatsApp(ctx, []string{number})
+	if err == nil && !statuses[number].Registered {
+		err = fmt.Errorf("probe JID %s is not registered on WhatsApp", p.jid)
+	}
+
+	p.checkedAt = time.Now()
+	p.lastErr = err
+	return err
+}
+
+// Readiness handles /ready: the client must be connected
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	h.readiness(w, r, false)
+}
+
+// ProbedReadiness handles /readyz: the client must be connected and, when
+// HEALTH_PROBE_JID is set, able to query WhatsApp's servers
+func (h *Handler) ProbedReadiness(w http.ResponseWriter, r *http.Request) {
+	h.readiness(w, r, true)
+}
+
+// readiness reports whether the client is connected, running the health probe when asked to
+func (h *Handler) readiness(w http.ResponseWriter, r *http.Request, probe bool) {
+	connected := h.waClient != nil && h.waClient.IsConnected()
+
+	response := &models.ReadinessResponse{
+		Status:    HealthOK,
+		Connected: connected,
+		Timestamp: time.Now().Unix(),
+	}
+
+	switch {
+	case !connected:
+		response.Status = HealthUnhealthy
+	case probe && h.healthProbe != nil:
+		response.ProbeJID = h.healthProbe.jid
+		if err := h.healthProbe.check(r.Context(), h); err != nil {
+			h.log.Warnf("Readiness probe failed: %v", err)
+			response.Status = HealthDegraded
+			response.ProbeError = err.Error()
+		}
+	}
+
+	status := http.StatusOK
+	if response.Status != HealthOK {
+		status = http.StatusServiceUnavailable
+	}
+	h.writeJSON(w, response, status)
+}
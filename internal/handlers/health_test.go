@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// fakeHealthSource reports a fixed connection status
+type fakeHealthSource struct {
+	status map[string]interface{}
+}
+
+func (f fakeHealthSource) GetConnectionStatus() map[string]interface{} { return f.status }
+func (f fakeHealthSource) LastSendResult() (time.Time, error)          { return time.Time{}, nil }
+func (f fakeHealthSource) ReconnectExhausted() (time.Time, bool)       { return time.Time{}, false }
+
+func TestHealthCheckEarlyStartup(t *testing.T) {
+	tests := []struct {
+		name   string
+		status map[string]interface{}
+	}{
+		{"nil status", nil},
+		{"missing connected", map[string]interface{}{"has_session": false}},
+		{"nil connected", map[string]interface{}{"connected": nil}},
+		{"non-bool connected", map[string]interface{}{"connected": "unknown"}},
+	}
+
+	for _, tt := range tests {
+		for _, path := range []string{"/health", "/health?detailed=true"} {
+			t.Run(tt.name+" "+path, func(t *testing.T) {
+				h := newTestHandler(nil)
+				h.health = fakeHealthSource{status: tt.status}
+
+				rec := httptest.NewRecorder()
+				h.HealthCheck(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+				if rec.Code != http.StatusOK {
+					t.Fatalf("status code = %d, want %d", rec.Code, http.StatusOK)
+				}
+				var response models.HealthResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+				}
+				if response.Connected {
+					t.Error("connected = true, want false")
+				}
+				if response.Status != HealthUnhealthy {
+					t.Errorf("status = %q, want %q", response.Status, HealthUnhealthy)
+				}
+			})
+		}
+	}
+}
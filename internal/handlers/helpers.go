@@ -1,21 +1,171 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"syscall"
 
+	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/middleware"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
-// writeJSON writes a JSON response with the given status code
+// defaultJSONBufferSize is the default size up to which JSON responses are buffered (1 MB)
+const defaultJSONBufferSize = 1 << 20
+
+// SetJSONBufferSize sets the size up to which JSON responses are buffered before
+// the status is written. Larger responses are streamed once they exceed it.
+func (h *Handler) SetJSONBufferSize(size int) {
+	if size <= 0 {
+		size = defaultJSONBufferSize
+	}
+	h.jsonBufferSize = size
+}
+
+// writeJSON writes a JSON response with the given status code.
+// Output is buffered up to the configured size so encoding failures can still
+// be reported as a 500; beyond that it is streamed to the client.
 func (h *Handler) writeJSON(w http.ResponseWriter, data interface{}, status int) {
+	bw := &bufferedResponseWriter{w: w, status: status, limit: h.jsonBufferSize}
+
+	err := json.NewEncoder(bw).Encode(data)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err == nil {
+		return
+	}
+
+	if isClientGone(err) {
+		h.log.Debugf("Client went away while writing JSON response: %v", err)
+		return
+	}
+
+	if bw.committed {
+		h.log.Error("Failed to encode JSON response after headers were sent", err)
+		return
+	}
+
+	h.log.Error("Failed to encode JSON response", err)
+	bw.buf.Reset()
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(&models.ErrorResponse{
+		Error: "Internal server error",
+		Code:  string(errors.ErrCodeInternalError),
+	})
+}
+
+// bufferedResponseWriter buffers a response body up to a limit, then commits the
+// status and switches to writing through to the underlying writer
+type bufferedResponseWriter struct {
+	w         http.ResponseWriter
+	status    int
+	limit     int
+	buf       bytes.Buffer
+	committed bool
+}
+
+func (bw *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if bw.committed {
+		return bw.w.Write(p)
+	}
+
+	bw.buf.Write(p)
+	if bw.buf.Len() <= bw.limit {
+		return len(p), nil
+	}
+
+	if err := bw.commit(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush commits the status and any buffered body
+func (bw *bufferedResponseWriter) Flush() error {
+	if bw.committed {
+		return nil
+	}
+	return bw.commit()
+}
+
+func (bw *bufferedResponseWriter) commit() error {
+	bw.committed = true
+	bw.w.Header().Set("Content-Type", "application/json")
+	bw.w.WriteHeader(bw.status)
+	_, err := bw.buf.WriteTo(bw.w)
+	return err
+}
+
+// isClientGone reports whether err means the client disconnected or the handler timed out
+func isClientGone(err error) bool {
+	return stderrors.Is(err, http.ErrHandlerTimeout) ||
+		stderrors.Is(err, syscall.EPIPE) ||
+		stderrors.Is(err, syscall.ECONNRESET)
+}
 
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.log.Error("Failed to encode JSON response", err)
+// Default and maximum page sizes for paginated list endpoints
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// parsePagination reads the 'limit' and 'offset' query parameters
+func parsePagination(r *http.Request) (limit, offset int, appErr *errors.AppError) {
+	query := r.URL.Query()
+
+	limit = defaultPageLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxPageLimit {
+			return 0, 0, errors.ValidationError(fmt.Sprintf("'limit' must be a number between 1 and %d", maxPageLimit))
+		}
+		limit = parsed
 	}
+
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			return 0, 0, errors.ValidationError("'offset' must be a non-negative number")
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
+
+// pageBounds returns the slice bounds of a page within total items
+func pageBounds(total, limit, offset int) (start, end int) {
+	start = min(offset, total)
+	end = min(start+limit, total)
+	return start, end
+}
+
+// sendFailed maps an error from a send to an application error
+func sendFailed(err error, to string) *errors.AppError {
+	if stderrors.Is(err, app.ErrNotGroupMember) {
+		return errors.NotGroupMember(to)
+	}
+	return errors.MessageSendFailed(err)
+}
+
+// queueFailed maps an error from queueing a send to an application error
+func queueFailed(err error) *errors.AppError {
+	if stderrors.Is(err, errSendQueueFull) {
+		return errors.SendQueueFull()
+	}
+	return errors.MessageSendFailed(err)
+}
+
+// NotFound handles requests to unregistered routes with a JSON error response
+func (h *Handler) NotFound(w http.ResponseWriter, r *http.Request) {
+	h.writeAppError(w, errors.New(errors.ErrCodeNotFound, "Route not found: "+r.Method+" "+r.URL.Path))
 }
 
 // writeAppError writes an application error response
@@ -24,10 +174,14 @@ func (h *Handler) writeAppError(w http.ResponseWriter, appErr *errors.AppError)
 		Error:   appErr.Message,
 		Code:    string(appErr.Code),
 		Details: appErr.Details,
+
+		// Set on the response header by the RequestID middleware before the handler runs
+		RequestID: w.Header().Get(middleware.RequestIDHeader),
 	}
 
 	// Log the error for internal monitoring
-	h.log.With("error_code", appErr.Code).
+	h.log.With("request_id", response.RequestID).
+		With("error_code", appErr.Code).
 		With("status_code", appErr.StatusCode).
 		Error(appErr.Message, appErr.Err)
 
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// redacted replaces secret values in recorded webhook deliveries
+const redacted = "[REDACTED]"
+
+// webhookDelivery is an authenticated webhook delivery kept for inspection and replay
+type webhookDelivery struct {
+	ID         string
+	ReceivedAt time.Time
+	Header     http.Header
+	Body       []byte
+	config     WebhookConfig
+	parse      func([]byte) (WebhookPayload, error)
+}
+
+// webhookHistory is a fixed-size ring buffer of the most recent webhook deliveries
+type webhookHistory struct {
+	mutex   sync.RWMutex
+	entries []webhookDelivery
+	next    int    // Index the next delivery is written to
+	count   int    // Number of deliveries currently held
+	lastID  uint64 // ID assigned to the most recent delivery
+}
+
+// newWebhookHistory creates a history holding up to size deliveries; zero disables it
+func newWebhookHistory(size int) *webhookHistory {
+	if size < 0 {
+		size = 0
+	}
+	return &webhookHistory{entries: make([]webhookDelivery, size)}
+}
+
+// add records a delivery with its secrets redacted, evicting the oldest when full
+func (wh *webhookHistory) add(header http.Header, body []byte, config WebhookConfig, parse func([]byte) (WebhookPayload, error)) {
+	if len(wh.entries) == 0 {
+		return
+	}
+
+	wh.mutex.Lock()
+	defer wh.mutex.Unlock()
+
+	wh.lastID++
+	wh.entries[wh.next] = webhookDelivery{
+		ID:         strconv.FormatUint(wh.lastID, 10),
+		ReceivedAt: time.Now(),
+		Header:     redactHeader(header, config),
+		Body:       redactBody(body),
+		config:     config,
+		parse:      parse,
+	}
+	wh.next = (wh.next + 1) % len(wh.entries)
+	if wh.count < len(wh.entries) {
+		wh.count++
+	}
+}
+
+// list returns the recorded deliveries, newest first
+func (wh *webhookHistory) list() []webhookDelivery {
+	wh.mutex.RLock()
+	defer wh.mutex.RUnlock()
+
+	deliveries := make([]webhookDelivery, 0, wh.count)
+	for i := 1; i <= wh.count; i++ {
+		deliveries = append(deliveries, wh.entries[(wh.next-i+len(wh.entries))%len(wh.entries)])
+	}
+	return deliveries
+}
+
+// get returns the recorded delivery with the given ID
+func (wh *webhookHistory) get(id string) (webhookDelivery, bool) {
+	for _, delivery := range wh.list() {
+		if delivery.ID == id {
+			return delivery, true
+		}
+	}
+	return webhookDelivery{}, false
+}
+
+// redactHeader copies the headers, masking signatures and credentials
+func redactHeader(header http.Header, config WebhookConfig) http.Header {
+	clone := header.Clone()
+	for _, name := range []string{"Authorization", "Cookie", "X-API-Key"} {
+		if clone.Get(name) != "" {
+			clone.Set(name, redacted)
+		}
+	}
+	for _, signatureHeader := range config.SignatureHeaders {
+		if clone.Get(signatureHeader.Name) != "" {
+			clone.Set(signatureHeader.Name, redacted)
+		}
+	}
+	if config.SecretHeader != "" && clone.Get(config.SecretHeader) != "" {
+		clone.Set(config.SecretHeader, redacted)
+	}
+	return clone
+}
+
+// redactBody masks a top-level "secret" field, which some providers (e.g. older Gitea) embed in the payload
+func redactBody(body []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	if _, ok := fields["secret"]; !ok {
+		return body
+	}
+
+	fields["secret"], _ = json.Marshal(redacted)
+	redactedBody, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+// SetWebhookHistorySize sets how many recent webhook deliveries are kept for replay; zero disables it
+func (h *Handler) SetWebhookHistorySize(size int) {
+	h.webhookHistory = newWebhookHistory(size)
+}
+
+// GetRecentWebhooks handles requests to list the most recent webhook deliveries
+func (h *Handler) GetRecentWebhooks(w http.ResponseWriter, r *http.Request) {
+	deliveries := h.webhookHistory.list()
+
+	response := make([]models.WebhookDeliveryInfo, len(deliveries))
+	for i, delivery := range deliveries {
+		headers := make(map[string]string, len(delivery.Header))
+		for name := range delivery.Header {
+			headers[name] = delivery.Header.Get(name)
+		}
+
+		response[i] = models.WebhookDeliveryInfo{
+			ID:         delivery.ID,
+			Provider:   string(delivery.config.Provider),
+			ReceivedAt: delivery.ReceivedAt.Unix(),
+			Headers:    headers,
+			Body:       string(delivery.Body),
+		}
+	}
+
+	h.writeJSON(w, response, http.StatusOK)
+}
+
+// ReplayWebhook handles requests to re-run a recorded webhook delivery through the webhook pipeline.
+// Authentication, duplicate detection and the delivery age check are skipped.
+func (h *Handler) ReplayWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	delivery, ok := h.webhookHistory.get(r.PathValue("id"))
+	if !ok {
+		h.writeAppError(w, errors.NotFound("Webhook delivery not found: "+r.PathValue("id")))
+		return
+	}
+
+	h.log.Infof("Replaying %s webhook delivery %s", delivery.config.Provider, delivery.ID)
+	h.processWebhook(w, r, delivery.Body, delivery.config, delivery.parse, false)
+}
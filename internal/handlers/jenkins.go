@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// ProviderJenkins identifies Jenkins build notifications
+const ProviderJenkins WebhookProvider = "Jenkins"
+
+// SetJenkinsConfig sets the Jenkins webhook token, recipient and priority
+func (h *Handler) SetJenkinsConfig(token, recipient, priority string) {
+	h.jenkinsToken = token
+	h.jenkinsRecipient = recipient
+	h.jenkinsPriority = models.Priority(priority).OrDefault()
+}
+
+// JenkinsWebhook handles Jenkins Notification plugin requests
+func (h *Handler) JenkinsWebhook(w http.ResponseWriter, r *http.Request) {
+	h.webhookMutex.RLock()
+	config := WebhookConfig{
+		Provider: ProviderJenkins,
+		// The Notification plugin doesn't sign payloads; a shared token is sent in a header instead
+		SecretHeader: "X-Jenkins-Token",
+		Secret:       h.jenkinsToken,
+		Recipient:    h.jenkinsRecipient,
+		Priority:     h.jenkinsPriority,
+	}
+	h.webhookMutex.RUnlock()
+
+	parsePayload := func(body []byte) (WebhookPayload, error) {
+		var payload models.JenkinsWebhookPayload
+		err := json.Unmarshal(body, &payload)
+		return payload, err
+	}
+
+	h.handleWebhook(w, r, config, parsePayload)
+}
+
+// formatJenkinsMessage constructs a WhatsApp message for a completed Jenkins build
+func formatJenkinsMessage(payload models.JenkinsWebhookPayload) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s Build *%s* #%d %s\n", jenkinsStatusEmoji(payload.Build.Status), payload.Name, payload.Build.Number, strings.ToLower(payload.Build.Status)))
+	sb.WriteString("\n```")
+	sb.WriteString(fmt.Sprintf("🏗️ Job    : %s\n", payload.Name))
+	sb.WriteString(fmt.Sprintf("🔢 Build  : #%d\n", payload.Build.Number))
+	sb.WriteString(fmt.Sprintf("📍 Phase  : %s\n", payload.Build.Phase))
+	sb.WriteString(fmt.Sprintf("📋 Status : %s\n", payload.Build.Status))
+	if branch := payload.Build.SCM.Branch; branch != "" {
+		sb.WriteString(fmt.Sprintf("🌿 Branch : %s\n", branch))
+	}
+	sb.WriteString("```\n")
+
+	if payload.Build.FullURL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 %s", payload.Build.FullURL))
+	}
+
+	return sb.String()
+}
+
+// jenkinsStatusEmoji returns the emoji for a Jenkins build status
+func jenkinsStatusEmoji(status string) string {
+	switch status {
+	case models.JenkinsStatusSuccess:
+		return "✅"
+	case models.JenkinsStatusFailure:
+		return "❌"
+	case models.JenkinsStatusUnstable:
+		return "⚠️"
+	case models.JenkinsStatusAborted:
+		return "⏹️"
+	}
+	return "❔"
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// defaultMaxMediaSize is the default maximum size of an uploaded media file (16 MB)
+const defaultMaxMediaSize = 16 << 20
+
+// maxMediaFieldSize is the maximum size of a non-file multipart field such as the caption
+const maxMediaFieldSize = 64 << 10
+
+// mediaUpload holds a parsed media send request. Multipart files are spooled to
+// a temporary file so the content never has to be held in memory in full.
+type mediaUpload struct {
+	To       string
+	Caption  string
+	FileName string
+	Mimetype string // As declared by the client, or detected from the content
+
+	content io.ReadSeeker // Positioned at the start of the file
+	size    int64
+	head    []byte   // Leading bytes of the content, for type detection
+	file    *os.File // Temporary file backing content, if any
+}
+
+// Close removes the temporary file backing the upload, if any
+func (u *mediaUpload) Close() {
+	if u.file != nil {
+		u.file.Close()
+		os.Remove(u.file.Name())
+	}
+}
+
+// SetMaxMediaSize sets the maximum accepted size of uploaded media in bytes
+func (h *Handler) SetMaxMediaSize(size int64) {
+	if size <= 0 {
+		size = defaultMaxMediaSize
+	}
+	h.maxMediaSize = size
+}
+
+// SendImage handles requests to send an image message
+func (h *Handler) SendImage(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	upload, appErr := h.readMediaUpload(w, r, "image")
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+	defer upload.Close()
+
+	// Check the actual content rather than the declared type
+	detected := http.DetectContentType(upload.head)
+	if !strings.HasPrefix(detected, "image/") {
+		h.writeAppError(w, errors.ValidationError(fmt.Sprintf("Unsupported image type: %s", detected)))
+		return
+	}
+
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
+	// Queue the send behind the per-recipient cooldown and higher-priority sends
+	ctx := r.Context()
+	release, err := h.acquireSend(ctx, upload.To, models.PriorityNormal)
+	if err != nil {
+		h.writeAppError(w, queueFailed(err))
+		return
+	}
+	defer release()
+
+	messageID, err := h.waClient.SendImage(ctx, upload.To, upload.content, detected, upload.Caption)
+	if err != nil {
+		h.log.Error("Failed to send image", err)
+		h.writeAppError(w, sendFailed(err, upload.To))
+		return
+	}
+
+	response := &models.SendMessageResponse{
+		Status:    "sent",
+		To:        upload.To,
+		MessageID: messageID,
+		Timestamp: time.Now().Unix(),
+	}
+	w.Header().Set("X-Message-ID", messageID)
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// SendDocument handles requests to send a document/file attachment
+func (h *Handler) SendDocument(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	upload, appErr := h.readMediaUpload(w, r, "document")
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+	defer upload.Close()
+
+	upload.FileName = strings.TrimSpace(filepath.Base(upload.FileName))
+	if upload.FileName == "" || upload.FileName == "." || upload.FileName == string(filepath.Separator) {
+		h.writeAppError(w, errors.ValidationError("'file_name' is required"))
+		return
+	}
+
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
+	// Queue the send behind the per-recipient cooldown and higher-priority sends
+	ctx := r.Context()
+	release, err := h.acquireSend(ctx, upload.To, models.PriorityNormal)
+	if err != nil {
+		h.writeAppError(w, queueFailed(err))
+		return
+	}
+	defer release()
+
+	messageID, err := h.waClient.SendDocument(ctx, upload.To, upload.content, upload.FileName, upload.Mimetype)
+	if err != nil {
+		h.log.Error("Failed to send document", err)
+		h.writeAppError(w, sendFailed(err, upload.To))
+		return
+	}
+
+	response := &models.SendMessageResponse{
+		Status:    "sent",
+		To:        upload.To,
+		MessageID: messageID,
+		Timestamp: time.Now().Unix(),
+	}
+	w.Header().Set("X-Message-ID", messageID)
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// readMediaUpload parses a media send request from either a multipart form
+// (with the file in fileField) or a JSON body with base64-encoded data.
+// The caller must Close the returned upload.
+func (h *Handler) readMediaUpload(w http.ResponseWriter, r *http.Request, fileField string) (*mediaUpload, *errors.AppError) {
+	// Allow for multipart and base64 overhead on top of the raw size limit
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxMediaSize*4/3+1<<20)
+
+	var upload *mediaUpload
+	var appErr *errors.AppError
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		upload, appErr = h.readMultipartMedia(r, fileField)
+	} else {
+		upload, appErr = h.readJSONMedia(r)
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := h.validateMediaUpload(upload); appErr != nil {
+		upload.Close()
+		return nil, appErr
+	}
+
+	return upload, nil
+}
+
+// validateMediaUpload checks the recipient and size of a parsed upload and fills in defaults
+func (h *Handler) validateMediaUpload(upload *mediaUpload) *errors.AppError {
+	upload.To = strings.TrimSpace(upload.To)
+	if upload.To == "" {
+		return errors.ValidationError("'to' field is required")
+	}
+	if !h.validator.IsValidJID(upload.To) {
+		return errors.InvalidJID(upload.To)
+	}
+
+	if upload.size == 0 {
+		return errors.ValidationError("Media file is required")
+	}
+	if upload.size > h.maxMediaSize {
+		return h.mediaTooLarge()
+	}
+
+	upload.Caption = h.validator.SanitizeMessage(upload.Caption)
+	if upload.Mimetype == "" || upload.Mimetype == "application/octet-stream" {
+		upload.Mimetype = http.DetectContentType(upload.head)
+	}
+
+	return nil
+}
+
+// mediaTooLarge returns the error for an upload over the size limit
+func (h *Handler) mediaTooLarge() *errors.AppError {
+	return errors.ValidationError(fmt.Sprintf("Media file too large (maximum %d bytes)", h.maxMediaSize))
+}
+
+// readMultipartMedia reads a media upload from a multipart form, streaming the
+// file part to a temporary file and rejecting it as soon as it exceeds the size limit
+func (h *Handler) readMultipartMedia(r *http.Request, fileField string) (*mediaUpload, *errors.AppError) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.InvalidRequest("Invalid multipart form: " + err.Error())
+	}
+
+	upload := &mediaUpload{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			upload.Close()
+			return nil, errors.InvalidRequest("Invalid multipart form: " + err.Error())
+		}
+
+		var appErr *errors.AppError
+		switch name := part.FormName(); {
+		case name == fileField && upload.file == nil:
+			upload.FileName = part.FileName()
+			upload.Mimetype = part.Header.Get("Content-Type")
+			appErr = h.spoolMediaPart(upload, part)
+		case name == "to" || name == "caption":
+			value, err := io.ReadAll(io.LimitReader(part, maxMediaFieldSize))
+			if err != nil {
+				appErr = errors.InvalidRequest("Invalid multipart form: " + err.Error())
+			} else if name == "to" {
+				upload.To = string(value)
+			} else {
+				upload.Caption = string(value)
+			}
+		}
+		part.Close()
+
+		if appErr != nil {
+			upload.Close()
+			return nil, appErr
+		}
+	}
+
+	if upload.file == nil {
+		return nil, errors.ValidationError(fmt.Sprintf("'%s' file is required", fileField))
+	}
+
+	return upload, nil
+}
+
+// spoolMediaPart copies a multipart file part to a temporary file, reading at
+// most one byte past the size limit so oversized uploads are cut off early
+func (h *Handler) spoolMediaPart(upload *mediaUpload, part io.Reader) *errors.AppError {
+	file, err := os.CreateTemp("", "whatsapp-notifier-upload-*")
+	if err != nil {
+		return errors.InternalError(err)
+	}
+	upload.file = file
+
+	size, err := io.Copy(file, io.LimitReader(part, h.maxMediaSize+1))
+	if err != nil {
+		return errors.InvalidRequest("Failed to read uploaded file: " + err.Error())
+	}
+	if size > h.maxMediaSize {
+		return h.mediaTooLarge()
+	}
+
+	head := make([]byte, 512)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return errors.InternalError(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return errors.InternalError(err)
+	}
+
+	upload.content = file
+	upload.size = size
+	upload.head = head[:n]
+	return nil
+}
+
+// readJSONMedia reads a media upload from a JSON body with base64-encoded data.
+// Unlike multipart uploads, the decoded content is held in memory.
+func (h *Handler) readJSONMedia(r *http.Request) (*mediaUpload, *errors.AppError) {
+	var req models.SendMediaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.InvalidRequest("Invalid request body: " + err.Error())
+	}
+
+	data, err := base64.StdEncoding.DecodeString(req.Data)
+	if err != nil {
+		return nil, errors.ValidationError("'data' must be base64-encoded")
+	}
+
+	return &mediaUpload{
+		To:       req.To,
+		Caption:  req.Caption,
+		FileName: req.FileName,
+		Mimetype: req.Mimetype,
+		content:  bytes.NewReader(data),
+		size:     int64(len(data)),
+		head:     data[:min(len(data), 512)],
+	}, nil
+}
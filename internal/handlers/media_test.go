@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// streamingMultipartRequest returns a multipart media request whose file part
+// is size bytes long, generated while the request is read rather than up front
+func streamingMultipartRequest(t *testing.T, fileField string, size int64) *http.Request {
+	t.Helper()
+
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	go func() {
+		err := form.WriteField("to", testRecipient)
+		if err == nil {
+			var part io.Writer
+			part, err = form.CreateFormFile(fileField, "large.bin")
+			chunk := []byte(strings.Repeat("x", 64<<10))
+			for written := int64(0); err == nil && written < size; written += int64(len(chunk)) {
+				_, err = part.Write(chunk[:min(int64(len(chunk)), size-written)])
+			}
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, "/send/document", pr)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+// tempFiles returns the names of the files in dir
+func tempFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names
+}
+
+func TestReadMediaUploadStreamsLargeFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	h := newTestHandler(nil)
+	size := h.maxMediaSize - 1024 // Just under the limit
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	upload, appErr := h.readMediaUpload(httptest.NewRecorder(), streamingMultipartRequest(t, "document", size), "document")
+
+	runtime.ReadMemStats(&after)
+
+	if appErr != nil {
+		t.Fatalf("readMediaUpload returned error: %s", appErr.Message)
+	}
+	defer upload.Close()
+
+	if upload.size != size {
+		t.Errorf("size = %d, want %d", upload.size, size)
+	}
+	if upload.file == nil || upload.content != upload.file {
+		t.Fatal("upload isn't backed by a temporary file")
+	}
+	if info, err := upload.file.Stat(); err != nil || info.Size() != size {
+		t.Errorf("temporary file size = %v (err %v), want %d", info.Size(), err, size)
+	}
+	if files := tempFiles(t, tmpDir); len(files) != 1 {
+		t.Errorf("temporary files = %v, want one upload file in TMPDIR", files)
+	}
+
+	// Everything allocated while reading, garbage included, must stay well below the file size
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > uint64(size)/8 {
+		t.Errorf("allocated %d bytes reading a %d byte upload; the file was buffered in memory", allocated, size)
+	}
+
+	upload.Close()
+	if files := tempFiles(t, tmpDir); len(files) != 0 {
+		t.Errorf("temporary files after Close = %v, want none", files)
+	}
+}
+
+func TestReadMediaUploadRejectsOversizeFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	h := newTestHandler(nil)
+	h.SetMaxMediaSize(1 << 20)
+
+	upload, appErr := h.readMediaUpload(httptest.NewRecorder(), streamingMultipartRequest(t, "document", h.maxMediaSize+1), "document")
+	if appErr == nil {
+		upload.Close()
+		t.Fatal("readMediaUpload accepted an upload over the size limit")
+	}
+	if want := h.mediaTooLarge().Message; appErr.Message != want {
+		t.Errorf("error = %q, want %q", appErr.Message, want)
+	}
+	if files := tempFiles(t, tmpDir); len(files) != 0 {
+		t.Errorf("temporary files after rejection = %v, want none", files)
+	}
+}
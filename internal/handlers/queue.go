@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// Default limits of the outbound send queue
+const (
+	defaultSendConcurrency       = 8
+	defaultLowPriorityQueueLimit = 100
+)
+
+// errSendQueueFull is returned when a low-priority send is shed because the queue is too deep
+var errSendQueueFull = stderrors.New("send queue is full")
+
+// sendQueue tracks outbound messages that are waiting to be sent or in flight, and
+// hands out a limited number of send slots, urgent messages first and low ones last
+type sendQueue struct {
+	mutex   sync.Mutex
+	nextID  uint64
+	pending map[uint64]time.Time // Entry ID to enqueue time
+
+	concurrency      int                // Sends allowed in flight at once (0 is unlimited)
+	lowPriorityLimit int                // Depth at which low-priority sends are rejected (0 disables shedding)
+	active           int                // Sends holding a slot
+	waiting          [3][]chan struct{} // Sends waiting for a slot, by priority rank then arrival
+}
+
+// QueueStats holds a snapshot of the outbound send queue
+type QueueStats struct {
+	Depth     int
+	OldestAge time.Duration
+}
+
+// newSendQueue creates an empty send queue with the default limits
+func newSendQueue() *sendQueue {
+	return &sendQueue{
+		pending:          make(map[uint64]time.Time),
+		concurrency:      defaultSendConcurrency,
+		lowPriorityLimit: defaultLowPriorityQueueLimit,
+	}
+}
+
+// SetSendQueueLimits sets how many sends may be in flight at once (0 is unlimited) and the
+// queue depth at which low-priority sends are rejected (0 never rejects them)
+func (h *Handler) SetSendQueueLimits(concurrency, lowPriorityLimit int) {
+	h.sendQueue.mutex.Lock()
+	defer h.sendQueue.mutex.Unlock()
+
+	h.sendQueue.concurrency = concurrency
+	h.sendQueue.lowPriorityLimit = lowPriorityLimit
+}
+
+// priorityRank orders priorities for the queue; lower ranks are served first
+func priorityRank(priority models.Priority) int {
+	switch priority {
+	case models.PriorityUrgent:
+		return 0
+	case models.PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// queueEntry is a send recorded in the queue
+type queueEntry struct {
+	queue    *sendQueue
+	id       uint64
+	priority models.Priority
+	slot     bool // Whether the entry holds a send slot
+}
+
+// enter records a pending send. Low-priority sends are rejected with errSendQueueFull
+// once the queue holds lowPriorityLimit sends.
+func (q *sendQueue) enter(priority models.Priority) (*queueEntry, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if priority == models.PriorityLow && q.lowPriorityLimit > 0 && len(q.pending) >= q.lowPriorityLimit {
+		return nil, errSendQueueFull
+	}
+
+	q.nextID++
+	q.pending[q.nextID] = time.Now()
+	return &queueEntry{queue: q, id: q.nextID, priority: priority}, nil
+}
+
+// join records a pending send and waits for a send slot, without a per-recipient cooldown
+func (q *sendQueue) join(ctx context.Context, priority models.Priority) (func(), error) {
+	entry, err := q.enter(priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := entry.acquire(ctx); err != nil {
+		entry.release()
+		return nil, err
+	}
+	return entry.release, nil
+}
+
+// acquire waits for a send slot. Slots go to waiting sends by priority, then in arrival order.
+func (e *queueEntry) acquire(ctx context.Context) error {
+	q := e.queue
+
+	q.mutex.Lock()
+	if q.concurrency <= 0 || (q.active < q.concurrency && q.waitingCount() == 0) {
+		q.active++
+		e.slot = true
+		q.mutex.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	rank := priorityRank(e.priority)
+	q.waiting[rank] = append(q.waiting[rank], ready)
+	q.mutex.Unlock()
+
+	select {
+	case <-ready:
+		e.slot = true
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, waiter := range q.waiting[rank] {
+		if waiter == ready {
+			q.waiting[rank] = append(q.waiting[rank][:i], q.waiting[rank][i+1:]...)
+			return ctx.Err()
+		}
+	}
+
+	// The slot was handed over while the context ended; pass it on
+	q.active--
+	q.grant()
+	return ctx.Err()
+}
+
+// release removes the send from the queue and frees its slot, if it holds one
+func (e *queueEntry) release() {
+	q := e.queue
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.pending, e.id)
+	if e.slot {
+		e.slot = false
+		q.active--
+		q.grant()
+	}
+}
+
+// grant hands free slots to the highest-priority waiting sends; the caller holds the mutex
+func (q *sendQueue) grant() {
+	for q.concurrency <= 0 || q.active < q.concurrency {
+		ready := q.nextWaiting()
+		if ready == nil {
+			return
+		}
+		q.active++
+		close(ready)
+	}
+}
+
+// nextWaiting removes and returns the first waiting send of the highest priority, if any
+func (q *sendQueue) nextWaiting() chan struct{} {
+	for rank := range q.waiting {
+		if len(q.waiting[rank]) > 0 {
+			ready := q.waiting[rank][0]
+			q.waiting[rank] = q.waiting[rank][1:]
+			return ready
+		}
+	}
+	return nil
+}
+
+// waitingCount returns the number of sends waiting for a slot
+func (q *sendQueue) waitingCount() int {
+	count := 0
+	for _, waiters := range q.waiting {
+		count += len(waiters)
+	}
+	return count
+}
+
+// Stats returns the current queue depth and the age of the oldest pending send
+func (q *sendQueue) Stats() QueueStats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	stats := QueueStats{Depth: len(q.pending)}
+	now := time.Now()
+	for _, enqueuedAt := range q.pending {
+		if age := now.Sub(enqueuedAt); age > stats.OldestAge {
+			stats.OldestAge = age
+		}
+	}
+	return stats
+}
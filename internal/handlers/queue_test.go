@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// waitForWaiting blocks until the queue has n sends waiting for a slot
+func waitForWaiting(t *testing.T, q *sendQueue, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		q.mutex.Lock()
+		count := q.waitingCount()
+		q.mutex.Unlock()
+		if count == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued sends", n)
+}
+
+func TestSendQueueServesByPriority(t *testing.T) {
+	q := newSendQueue()
+	q.concurrency = 1
+
+	// Hold the only slot so the following sends have to wait
+	busy, err := q.join(context.Background(), models.PriorityNormal)
+	if err != nil {
+		t.Fatalf("join() returned error: %v", err)
+	}
+
+	arrivals := []models.Priority{
+		models.PriorityLow,
+		models.PriorityNormal,
+		models.PriorityLow,
+		models.PriorityUrgent,
+		models.PriorityNormal,
+	}
+	served := make(chan models.Priority, len(arrivals))
+	for i, priority := range arrivals {
+		go func() {
+			release, err := q.join(context.Background(), priority)
+			if err != nil {
+				t.Errorf("join(%s) returned error: %v", priority, err)
+				return
+			}
+			served <- priority
+			release()
+		}()
+		waitForWaiting(t, q, i+1)
+	}
+
+	busy()
+
+	want := []models.Priority{
+		models.PriorityUrgent,
+		models.PriorityNormal,
+		models.PriorityNormal,
+		models.PriorityLow,
+		models.PriorityLow,
+	}
+	for i, priority := range want {
+		select {
+		case got := <-served:
+			if got != priority {
+				t.Fatalf("send %d served with priority %s, want %s", i, got, priority)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for send %d", i)
+		}
+	}
+}
+
+func TestSendQueueCancelledWaitFreesPlace(t *testing.T) {
+	q := newSendQueue()
+	q.concurrency = 1
+
+	busy, err := q.join(context.Background(), models.PriorityNormal)
+	if err != nil {
+		t.Fatalf("join() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.join(ctx, models.PriorityUrgent); !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("join() with an expiring context returned %v, want context.DeadlineExceeded", err)
+	}
+
+	busy()
+	if stats := q.Stats(); stats.Depth != 0 {
+		t.Errorf("Stats().Depth = %d after all sends finished, want 0", stats.Depth)
+	}
+
+	release, err := q.join(context.Background(), models.PriorityNormal)
+	if err != nil {
+		t.Fatalf("join() after the cancelled wait returned error: %v", err)
+	}
+	release()
+}
+
+func TestAcquireSendShedsLowPriority(t *testing.T) {
+	h := newTestHandler(nil)
+	h.SetSendQueueLimits(1, 2)
+
+	ctx := context.Background()
+	first, err := h.acquireSend(ctx, testRecipient, models.PriorityNormal)
+	if err != nil {
+		t.Fatalf("acquireSend(normal) returned error: %v", err)
+	}
+
+	// Fill the queue to its limit with a send waiting for the slot
+	waiting := make(chan func())
+	go func() {
+		release, err := h.acquireSend(ctx, testRecipient, models.PriorityNormal)
+		if err != nil {
+			t.Errorf("acquireSend(normal) returned error: %v", err)
+		}
+		waiting <- release
+	}()
+	waitForWaiting(t, h.sendQueue, 1)
+
+	_, err = h.acquireSend(ctx, testRecipient, models.PriorityLow)
+	if !stderrors.Is(err, errSendQueueFull) {
+		t.Fatalf("acquireSend(low) on a full queue returned %v, want errSendQueueFull", err)
+	}
+	appErr := queueFailed(err)
+	if appErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("queueFailed() status = %d, want %d", appErr.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	// Normal and urgent sends are never shed
+	urgentCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := h.acquireSend(urgentCtx, testRecipient, models.PriorityUrgent); stderrors.Is(err, errSendQueueFull) {
+		t.Error("acquireSend(urgent) on a full queue was shed")
+	}
+
+	// Once the queue drains, low-priority sends are accepted again
+	first()
+	(<-waiting)()
+	release, err := h.acquireSend(ctx, testRecipient, models.PriorityLow)
+	if err != nil {
+		t.Fatalf("acquireSend(low) after the queue drained returned error: %v", err)
+	}
+	release()
+}
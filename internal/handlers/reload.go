@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// ApplyWebhookConfig sets the webhook secrets, recipients, priorities and keyword routes
+// from cfg in one step, so in-flight deliveries see either the old or the new settings
+func (h *Handler) ApplyWebhookConfig(cfg *config.Config) {
+	h.webhookMutex.Lock()
+	defer h.webhookMutex.Unlock()
+
+	h.giteaSecret = cfg.Gitea.WebhookSecret
+	h.giteaRecipient = cfg.Gitea.Recipient
+	h.githubSecret = cfg.GitHub.WebhookSecret
+	h.githubRecipient = cfg.GitHub.Recipient
+	h.SetWebhookPriorities(cfg.Gitea.Priority, cfg.GitHub.Priority)
+	h.SetGiteaPayloadSecret(cfg.Gitea.PayloadSecret)
+	h.SetSignatureHeaders(cfg.Gitea.SignatureHeader, cfg.GitHub.SignatureHeader)
+	h.SetBitbucketConfig(cfg.Bitbucket.WebhookSecret, cfg.Bitbucket.Recipient, cfg.Bitbucket.Priority)
+	h.SetJenkinsConfig(cfg.Jenkins.WebhookToken, cfg.Jenkins.Recipient, cfg.Jenkins.Priority)
+	h.SetAlertmanagerConfig(cfg.Alertmanager.BearerToken, cfg.Alertmanager.Recipient, cfg.Alertmanager.Priority)
+	h.SetCustomWebhookConfig(
+		cfg.Custom.Template,
+		cfg.Custom.Secret,
+		SignatureHeader{Name: cfg.Custom.SignatureHeader, Prefix: cfg.Custom.SignaturePrefix},
+		cfg.Custom.Recipient,
+		cfg.Custom.Priority,
+	)
+	h.SetMessageTemplate(ProviderGitea, cfg.Gitea.MessageTemplate)
+	h.SetMessageTemplate(ProviderGitHub, cfg.GitHub.MessageTemplate)
+	h.SetKeywordRoutes(cfg.Routing.KeywordRoutes, cfg.Routing.Mode == "replace")
+	h.forcePushRecipient = cfg.Routing.ForcePushRecipient
+}
+
+// OnReload registers a function called with each successfully reloaded configuration,
+// for components outside the handler (e.g. the API key middleware)
+func (h *Handler) OnReload(hook func(*config.Config)) {
+	h.reloadHooks = append(h.reloadHooks, hook)
+}
+
+// Reload re-reads the configuration and applies the webhook settings, raw API keys
+// and registered hooks without a restart. Other settings still need a restart. The
+// current settings are kept if the new configuration is invalid.
+func (h *Handler) Reload() error {
+	cfg, err := config.Reload()
+	if err != nil {
+		h.log.Warnf("Configuration reload rejected: %v", err)
+		return err
+	}
+
+	h.ApplyWebhookConfig(cfg)
+	h.SetRawAPIKeys(cfg.Security.RawAPIKeys)
+	for _, hook := range h.reloadHooks {
+		hook(cfg)
+	}
+
+	h.log.Info("Configuration reloaded")
+	return nil
+}
+
+// ReloadConfig handles requests to reload the configuration (see Reload)
+func (h *Handler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	if err := h.Reload(); err != nil {
+		h.writeAppError(w, errors.ValidationError("Invalid configuration: "+err.Error()))
+		return
+	}
+
+	h.writeJSON(w, &models.ReloadConfigResponse{
+		Status:    "reloaded",
+		Timestamp: time.Now().Unix(),
+	}, http.StatusOK)
+}
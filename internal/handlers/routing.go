@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// keywordRoute is a compiled keyword route
+type keywordRoute struct {
+	pattern   string
+	regex     *regexp.Regexp // nil for plain substring patterns
+	recipient string
+}
+
+// matches reports whether the route matches a commit message
+func (kr keywordRoute) matches(message string) bool {
+	if kr.regex != nil {
+		return kr.regex.MatchString(message)
+	}
+	return strings.Contains(message, kr.pattern)
+}
+
+// SetKeywordRoutes sets the commit message keyword routes for webhook notifications.
+// Patterns are validated by config.Validate, so invalid regular expressions are skipped.
+func (h *Handler) SetKeywordRoutes(routes []config.KeywordRoute, replaceDefault bool) {
+	compiled := make([]keywordRoute, 0, len(routes))
+	for _, route := range routes {
+		kr := keywordRoute{pattern: route.Pattern, recipient: route.Recipient}
+		if expr, ok := strings.CutPrefix(route.Pattern, "re:"); ok {
+			regex, err := regexp.Compile(expr)
+			if err != nil {
+				h.log.Warnf("Skipping keyword route with invalid pattern %q: %v", route.Pattern, err)
+				continue
+			}
+			kr.regex = regex
+		}
+		compiled = append(compiled, kr)
+	}
+
+	h.keywordRoutes = compiled
+	h.replaceDefaultRecipient = replaceDefault
+}
+
+// SetBranchDisplayPattern sets the regular expression that extracts the branch name shown
+// in push notifications. The pattern is validated by config.Validate, so an invalid
+// pattern is skipped and full branch names are shown.
+func (h *Handler) SetBranchDisplayPattern(pattern string) {
+	h.branchDisplay = nil
+	if pattern == "" {
+		return
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		h.log.Warnf("Skipping invalid branch display pattern %q: %v", pattern, err)
+		return
+	}
+	h.branchDisplay = regex
+}
+
+// displayBranch returns the first capture group of the branch display pattern, or the whole
+// match if it has no groups. Branches that don't match are shown in full.
+func (h *Handler) displayBranch(branch string) string {
+	if h.branchDisplay == nil {
+		return branch
+	}
+
+	match := h.branchDisplay.FindStringSubmatch(branch)
+	switch {
+	case match == nil:
+		return branch
+	case len(match) > 1 && match[1] != "":
+		return match[1]
+	case match[0] != "":
+		return match[0]
+	}
+	return branch
+}
+
+// SetBranchFilter sets the glob patterns (as in path.Match, e.g. "release/*") of branches
+// that trigger webhook notifications. An empty list allows every branch.
+func (h *Handler) SetBranchFilter(patterns []string) {
+	h.branchFilter = patterns
+}
+
+// branchAllowed reports whether notifications for the branch pass the branch filter.
+// Events without a branch (e.g. issues) always pass.
+func (h *Handler) branchAllowed(branch string) bool {
+	if len(h.branchFilter) == 0 || branch == "" {
+		return true
+	}
+	for _, pattern := range h.branchFilter {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRecipients returns the recipients for a webhook notification.
+// Every route whose pattern matches any commit message applies, in configuration
+// order, with duplicates removed. Force pushes are also routed to FORCE_PUSH_RECIPIENT
+// as if it were the first route. The default recipient comes first unless replace
+// mode is enabled and at least one route matched.
+func (h *Handler) resolveRecipients(defaultRecipient string, commits []models.CommitInfo, forced bool) []string {
+	h.webhookMutex.RLock()
+	defer h.webhookMutex.RUnlock()
+
+	matched := make([]string, 0)
+	seen := make(map[string]bool)
+
+	if forced && h.forcePushRecipient != "" {
+		seen[h.forcePushRecipient] = true
+		matched = append(matched, h.forcePushRecipient)
+	}
+
+	for _, route := range h.keywordRoutes {
+		if seen[route.recipient] {
+			continue
+		}
+		for _, commit := range commits {
+			if route.matches(commit.Message) {
+				seen[route.recipient] = true
+				matched = append(matched, route.recipient)
+				break
+			}
+		}
+	}
+
+	if len(matched) > 0 && h.replaceDefaultRecipient {
+		return matched
+	}
+
+	recipients := []string{defaultRecipient}
+	for _, recipient := range matched {
+		if recipient != defaultRecipient {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
+	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
+)
+
+// scheduledSendTimeout bounds how long a due scheduled message may take to send
+const scheduledSendTimeout = 2 * time.Minute
+
+// scheduledMessage is a validated send request waiting for its send time
+type scheduledMessage struct {
+	id     string
+	req    models.SendMessageRequest
+	sendAt time.Time
+	timer  *time.Timer
+}
+
+// messageScheduler holds pending scheduled messages in memory, each with its own timer
+type messageScheduler struct {
+	mutex   sync.Mutex
+	pending map[string]*scheduledMessage
+	nextID  int64
+	stopped bool
+}
+
+// newMessageScheduler creates an empty scheduler
+func newMessageScheduler() *messageScheduler {
+	return &messageScheduler{pending: make(map[string]*scheduledMessage)}
+}
+
+// add schedules dispatch to run with the message once its send time arrives
+func (s *messageScheduler) add(req models.SendMessageRequest, sendAt time.Time, dispatch func(*scheduledMessage)) (*scheduledMessage, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return nil, false
+	}
+
+	s.nextID++
+	msg := &scheduledMessage{id: strconv.FormatInt(s.nextID, 10), req: req, sendAt: sendAt}
+	msg.timer = time.AfterFunc(time.Until(sendAt), func() {
+		if s.take(msg.id) {
+			dispatch(msg)
+		}
+	})
+	s.pending[msg.id] = msg
+	return msg, true
+}
+
+// take removes a pending message, reporting whether it was still pending
+func (s *messageScheduler) take(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.pending[id]; !ok {
+		return false
+	}
+	delete(s.pending, id)
+	return true
+}
+
+// cancel stops a pending message before it is sent
+func (s *messageScheduler) cancel(id string) (*scheduledMessage, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msg, ok := s.pending[id]
+	if !ok {
+		return nil, false
+	}
+	msg.timer.Stop()
+	delete(s.pending, id)
+	return msg, true
+}
+
+// stop cancels every pending message and rejects new ones, returning how many were dropped
+func (s *messageScheduler) stop() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stopped = true
+	dropped := len(s.pending)
+	for id, msg := range s.pending {
+		msg.timer.Stop()
+		delete(s.pending, id)
+	}
+	return dropped
+}
+
+// ScheduleMessage handles requests to send a message at a future time
+func (h *Handler) ScheduleMessage(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	// Parse request body
+	var req models.ScheduleMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	// Validate request
+	if appErr := h.validator.ValidateSendMessageRequest(&req.SendMessageRequest); appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	sendAt, err := time.Parse(time.RFC3339, req.SendAt)
+	if err != nil {
+		h.writeAppError(w, errors.ValidationError("'send_at' must be an RFC3339 timestamp"))
+		return
+	}
+	if !sendAt.After(time.Now()) {
+		h.writeAppError(w, errors.ValidationError("'send_at' must be in the future"))
+		return
+	}
+
+	// Sanitize message unless a trusted caller asked for it verbatim
+	if req.Raw {
+		if !h.canSendRaw(r) {
+			h.writeAppError(w, errors.New(errors.ErrCodeForbidden, "API key is not allowed to send raw messages"))
+			return
+		}
+	} else {
+		req.Message = h.validator.SanitizeMessage(req.Message)
+	}
+	req.Priority = req.Priority.OrDefault()
+
+	msg, ok := h.scheduler.add(req.SendMessageRequest, sendAt, h.sendScheduledMessage)
+	if !ok {
+		h.writeAppError(w, errors.New(errors.ErrCodeServiceUnavailable, "Server is shutting down"))
+		return
+	}
+
+	h.log.Infof("Scheduled message %s to %s for %s", msg.id, req.To, sendAt.Format(time.RFC3339))
+	h.writeJSON(w, &models.ScheduleMessageResponse{
+		ID:       msg.id,
+		Status:   "scheduled",
+		To:       req.To,
+		Priority: req.Priority,
+		SendAt:   sendAt.Unix(),
+	}, http.StatusAccepted)
+}
+
+// CancelScheduledMessage handles requests to cancel a pending scheduled message
+func (h *Handler) CancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use DELETE"))
+		return
+	}
+
+	msg, ok := h.scheduler.cancel(r.PathValue("id"))
+	if !ok {
+		h.writeAppError(w, errors.NotFound("Scheduled message not found or already sent: "+r.PathValue("id")))
+		return
+	}
+
+	h.log.Infof("Cancelled scheduled message %s to %s", msg.id, msg.req.To)
+	h.writeJSON(w, &models.ScheduleMessageResponse{
+		ID:       msg.id,
+		Status:   "cancelled",
+		To:       msg.req.To,
+		Priority: msg.req.Priority,
+		SendAt:   msg.sendAt.Unix(),
+	}, http.StatusOK)
+}
+
+// sendScheduledMessage sends a due scheduled message, reconnecting first if needed since it runs off a timer
+func (h *Handler) sendScheduledMessage(msg *scheduledMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), scheduledSendTimeout)
+	defer cancel()
+
+	if err := h.waClient.EnsureConnected(ctx); err != nil {
+		h.log.Errorf("Failed to send scheduled message %s to %s: %v", msg.id, msg.req.To, err)
+		return
+	}
+
+	release, err := h.acquireSend(ctx, msg.req.To, msg.req.Priority)
+	if err != nil {
+		h.log.Errorf("Failed to send scheduled message %s to %s: %v", msg.id, msg.req.To, err)
+		return
+	}
+	defer release()
+
+	opts := h.sendOptions(msg.req.Forwarded)
+	opts.QuotedMessageID = msg.req.QuotedMessageID
+	opts.QuotedJID = msg.req.QuotedJID
+	opts.DisappearingTimer = msg.req.DisappearingTimer
+	opts.SimulateTyping = msg.req.SimulateTyping
+	opts.OmitFooter = msg.req.OmitFooter
+
+	messageID, err := h.waClient.SendTextWithOptions(ctx, msg.req.To, msg.req.Message, opts)
+	if err != nil {
+		h.log.Errorf("Failed to send scheduled message %s to %s: %v", msg.id, msg.req.To, err)
+		return
+	}
+	h.log.Infof("Scheduled message %s sent to %s (message_id: %s)", msg.id, msg.req.To, messageID)
+}
+
+// StopScheduledMessages cancels all pending scheduled messages; they are not persisted
+func (h *Handler) StopScheduledMessages() {
+	if dropped := h.scheduler.stop(); dropped > 0 {
+		h.log.Warnf("Dropped %d pending scheduled message(s) on shutdown", dropped)
+	}
+}
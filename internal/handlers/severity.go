@@ -0,0 +1,52 @@
+package handlers
+
+import "strings"
+
+// defaultSeverityEmoji maps alert severities to the emoji prefixed to alert notifications
+var defaultSeverityEmoji = map[string]string{
+	"critical": "🔴",
+	"error":    "🟠",
+	"warning":  "🟡",
+	"info":     "🔵",
+}
+
+// unknownSeverityEmoji is used for severities without a mapping, and for repositories without an icon
+const unknownSeverityEmoji = "🔔"
+
+// SetSeverityEmoji overrides the emoji used per alert severity, keeping the
+// built-in mapping for severities that aren't configured
+func (h *Handler) SetSeverityEmoji(mapping map[string]string) {
+	merged := make(map[string]string, len(defaultSeverityEmoji)+len(mapping))
+	for severity, emoji := range defaultSeverityEmoji {
+		merged[severity] = emoji
+	}
+	for severity, emoji := range mapping {
+		merged[strings.ToLower(severity)] = emoji
+	}
+	h.severityEmoji = merged
+}
+
+// SetRepoIcons sets the emoji that leads push notifications per repository full name
+func (h *Handler) SetRepoIcons(icons map[string]string) {
+	normalized := make(map[string]string, len(icons))
+	for repo, icon := range icons {
+		normalized[strings.ToLower(repo)] = icon
+	}
+	h.repoIcons = normalized
+}
+
+// iconForRepo returns the configured emoji for a repository, or the default bell
+func (h *Handler) iconForRepo(repo string) string {
+	if icon, ok := h.repoIcons[strings.ToLower(repo)]; ok && icon != "" {
+		return icon
+	}
+	return unknownSeverityEmoji
+}
+
+// emojiForSeverity returns the emoji/prefix for an alert severity
+func (h *Handler) emojiForSeverity(severity string) string {
+	if emoji, ok := h.severityEmoji[strings.ToLower(strings.TrimSpace(severity))]; ok {
+		return emoji
+	}
+	return unknownSeverityEmoji
+}
@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
@@ -21,13 +26,24 @@ const (
 	ProviderGitHub WebhookProvider = "GitHub"
 )
 
+// SignatureHeader describes a header that may carry the webhook signature
+type SignatureHeader struct {
+	Name   string
+	Prefix string // e.g., "sha256=" for GitHub
+}
+
 // WebhookConfig holds configuration for webhook processing
 type WebhookConfig struct {
-	Provider        WebhookProvider
-	SignatureHeader string
-	Secret          string
-	Recipient       string
-	SignaturePrefix string // e.g., "sha256=" for GitHub
+	Provider           WebhookProvider
+	DeliveryHeader     string            // Header carrying the provider's unique delivery ID
+	SignatureHeaders   []SignatureHeader // Accepted signature headers in precedence order; the first present is authoritative
+	SecretQueryParam   string            // If set, the secret is compared with this query parameter instead of a signature
+	SecretHeader       string            // If set, the secret is compared with this header instead of a signature
+	SecretHeaderPrefix string            // Required prefix before the secret in SecretHeader, e.g. "Bearer "
+	PayloadSecret      string            // If set, a top-level payload field compared with the secret when no signature header is sent
+	Secret             string
+	Recipient          string
+	Priority           models.Priority // Delivery priority for notifications
 }
 
 // WebhookPayload is a generic interface for webhook payloads
@@ -39,18 +55,38 @@ type WebhookPayload interface {
 	GetCommits() []models.CommitInfo
 	GetCompareURL() string
 	GetFileChangeSummary() models.FileChangeSummary
+	GetTimestamp() time.Time
 }
 
-// handleWebhook is a generic webhook handler that processes both Gitea and GitHub webhooks
-func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request, config WebhookConfig, parsePayload func([]byte) (WebhookPayload, error)) {
-	// Get signature from header
-	headerSignature := r.Header.Get(config.SignatureHeader)
-	if headerSignature == "" {
-		h.log.Warnf("%s webhook received without signature header", config.Provider)
-		h.writeAppError(w, errors.New(errors.ErrCodeUnauthorized, fmt.Sprintf("Missing %s header", config.SignatureHeader)))
-		return
-	}
+// notifiablePayload is implemented by payloads that only warrant a notification for some events
+type notifiablePayload interface {
+	ShouldNotify() bool
+}
+
+// recipientPayload is implemented by payloads that name their own recipient
+type recipientPayload interface {
+	GetRecipient() string
+}
 
+// forcedPayload is implemented by push payloads that report force pushes
+type forcedPayload interface {
+	IsForced() bool
+}
+
+// isForcePush reports whether the payload is a force push
+func isForcePush(payload WebhookPayload) bool {
+	forced, ok := payload.(forcedPayload)
+	return ok && forced.IsForced()
+}
+
+// pusherSourcePayload is implemented by payloads that can report the commit author or
+// committer in place of the pusher
+type pusherSourcePayload interface {
+	GetPusherNameFrom(source string) string
+}
+
+// handleWebhook is a generic webhook handler that processes webhooks from all providers
+func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request, config WebhookConfig, parsePayload func([]byte) (WebhookPayload, error)) {
 	// Read the raw body for signature verification
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -58,29 +94,109 @@ func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request, config W
 		return
 	}
 
-	// Verify webhook signature
-	if !h.verifyWebhookSignature(body, headerSignature, config) {
-		h.log.Warnf("Invalid %s webhook signature", config.Provider)
-		h.writeAppError(w, errors.New(errors.ErrCodeUnauthorized, "Invalid webhook signature"))
+	// Authenticate the delivery
+	if appErr := h.authenticateWebhook(r, body, config); appErr != nil {
+		h.writeAppError(w, appErr)
 		return
 	}
 
 	h.log.Infof("%s webhook received", config.Provider)
 
+	// Dry runs neither count as a delivery nor end up in the history
+	dryRun := isDryRunRequest(r)
+
+	// Short-circuit retry storms of the same delivery without reprocessing it. Deliveries
+	// without an ID can't be told apart, so they are never treated as repeats.
+	deliveryKey := ""
+	if deliveryID := r.Header.Get(config.DeliveryHeader); config.DeliveryHeader != "" && deliveryID != "" && !dryRun {
+		deliveryKey = string(config.Provider) + ":" + deliveryID
+		if h.deliveries.seenRecently(deliveryKey) {
+			h.log.Warnf("Ignoring repeated %s webhook delivery %s", config.Provider, deliveryID)
+			h.writeJSON(w, &models.WebhookResponse{
+				Status:    "duplicate delivery ignored",
+				Provider:  string(config.Provider),
+				Recipient: config.Recipient,
+			}, http.StatusOK)
+			return
+		}
+	}
+
+	// Form-encoded deliveries carry the JSON in a form field; the signature covers the raw body
+	body, appErr := extractWebhookJSON(r, body)
+	if appErr != nil {
+		h.deliveries.forget(deliveryKey)
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	// Keep the delivery so it can be inspected and replayed
+	if !dryRun {
+		h.webhookHistory.add(r.Header, body, config, parsePayload)
+	}
+
+	// Forget failed deliveries so the provider's retry is processed rather than ignored
+	if !h.processWebhook(w, r, body, config, parsePayload, true) {
+		h.deliveries.forget(deliveryKey)
+	}
+}
+
+// processWebhook parses an authenticated delivery and sends its notification, reporting
+// whether it was handled (sent, queued or deliberately ignored) rather than rejected or
+// failed. checkAge is false for replays, which are expected to be old.
+func (h *Handler) processWebhook(w http.ResponseWriter, r *http.Request, body []byte, config WebhookConfig, parsePayload func([]byte) (WebhookPayload, error), checkAge bool) bool {
 	// Parse webhook payload using provider-specific parser
 	payload, err := parsePayload(body)
 	if err != nil {
 		h.writeAppError(w, errors.InvalidRequest("Invalid webhook payload: "+err.Error()))
-		return
+		return false
+	}
+
+	// Payloads that name their recipient override the configured one
+	if addressed, ok := payload.(recipientPayload); ok {
+		config.Recipient = addressed.GetRecipient()
+	}
+
+	// Reject stale deliveries to mitigate replays
+	if checkAge {
+		if appErr := h.checkDeliveryAge(r, payload); appErr != nil {
+			h.log.Warnf("Rejected stale %s webhook delivery: %s", config.Provider, appErr.Message)
+			h.writeAppError(w, appErr)
+			return false
+		}
+	}
+
+	// Skip events that don't warrant a notification (e.g. builds that haven't completed)
+	if notifiable, ok := payload.(notifiablePayload); ok && !notifiable.ShouldNotify() {
+		h.log.Infof("%s webhook event doesn't warrant a notification, skipping", config.Provider)
+		h.writeJSON(w, &models.WebhookResponse{
+			Status:     "ignored",
+			Provider:   string(config.Provider),
+			Recipient:  config.Recipient,
+			Repository: payload.GetRepositoryName(),
+		}, http.StatusOK)
+		return true
 	}
 
-	// Ensure client is connected
-	if !h.waClient.IsConnected() {
-		ctx := r.Context()
-		if err := h.waClient.EnsureConnected(ctx); err != nil {
+	// Skip branches outside the branch filter
+	if branch := payload.GetBranch(); !h.branchAllowed(branch) {
+		h.log.Infof("%s webhook for branch %s doesn't match the branch filter, skipping", config.Provider, branch)
+		h.writeJSON(w, &models.WebhookResponse{
+			Status:     "ignored",
+			Provider:   string(config.Provider),
+			Recipient:  config.Recipient,
+			Repository: payload.GetRepositoryName(),
+		}, http.StatusOK)
+		return true
+	}
+
+	dryRun := isDryRunRequest(r)
+
+	// Ensure client is connected and past its ready grace period
+	if !dryRun {
+		if err := h.waClient.EnsureConnected(r.Context()); err != nil {
 			h.log.Error("Failed to connect client", err)
 			h.writeAppError(w, errors.ConnectionFailed(err))
-			return
+			return false
 		}
 	}
 
@@ -88,23 +204,321 @@ func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request, config W
 	message := h.formatWebhookMessage(payload, config.Provider)
 	if message == "" {
 		h.writeAppError(w, errors.InvalidRequest("Webhook payload has no commits to notify"))
-		return
+		return false
+	}
+
+	recipients := h.resolveRecipients(config.Recipient, payload.GetCommits(), isForcePush(payload))
+
+	// In dry-run mode, return the formatted message without sending it
+	if dryRun {
+		h.log.Infof("%s webhook dry run, notification not sent", config.Provider)
+		response := &models.WebhookResponse{
+			Status:     "dry run",
+			Provider:   string(config.Provider),
+			Recipient:  recipients[0],
+			Repository: payload.GetRepositoryName(),
+			Message:    message,
+		}
+		if len(recipients) > 1 {
+			response.Recipients = recipients
+		}
+		h.writeJSON(w, response, http.StatusOK)
+		return true
 	}
 
-	// Send message to configured recipient
+	// In digest mode, buffer the notification for the next combined message
+	if digest, ok := h.digests[config.Provider]; ok {
+		for _, recipient := range recipients {
+			digest.add(recipient, payload.GetRepositoryName(), message, config)
+		}
+
+		h.log.Infof("%s webhook notification queued for digest", config.Provider)
+		response := &models.WebhookResponse{
+			Status:     "queued for digest",
+			Provider:   string(config.Provider),
+			Recipient:  recipients[0],
+			Repository: payload.GetRepositoryName(),
+		}
+		if len(recipients) > 1 {
+			response.Recipients = recipients
+		}
+		if h.isDebugRequest(r, config) {
+			response.Message = message
+		}
+		h.writeJSON(w, response, http.StatusOK)
+		return true
+	}
+
+	// Send message to every routed recipient, skipping those just sent the same content
 	ctx := r.Context()
-	if err := h.waClient.SendText(ctx, config.Recipient, message); err != nil {
-		h.log.Errorf("Failed to send %s webhook notification: %v", config.Provider, err)
-		h.writeAppError(w, errors.MessageSendFailed(err))
-		return
+	messageIDs := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if h.contentDedupe.isDuplicate(recipient, message) {
+			h.log.Warnf("Suppressed %s webhook notification to %s: identical to the previous notification", config.Provider, recipient)
+			continue
+		}
+
+		messageID, err := h.sendWebhookNotification(ctx, recipient, message, config)
+		if err != nil {
+			h.log.Errorf("Failed to send %s webhook notification to %s: %v", config.Provider, recipient, err)
+			h.writeAppError(w, sendFailed(err, recipient))
+			return false
+		}
+		h.contentDedupe.record(recipient, message)
+
+		h.log.Infof("%s webhook notification sent to %s (priority: %s, message_id: %s)", config.Provider, recipient, config.Priority, messageID)
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	response := &models.WebhookResponse{
+		Status:     "notification sent",
+		Provider:   string(config.Provider),
+		Recipient:  recipients[0],
+		Repository: payload.GetRepositoryName(),
+	}
+	if len(messageIDs) > 0 {
+		response.MessageID = messageIDs[0]
+	} else {
+		response.Status = "duplicate content suppressed"
+	}
+	if len(recipients) > 1 {
+		response.Recipients = recipients
+	}
+	if h.isDebugRequest(r, config) {
+		response.Message = message
+	}
+	h.writeJSON(w, response, http.StatusOK)
+	return true
+}
+
+// extractWebhookJSON returns the JSON payload of a delivery, unwrapping the
+// "payload" field of application/x-www-form-urlencoded bodies
+func extractWebhookJSON(r *http.Request, body []byte) ([]byte, *errors.AppError) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" {
+		return body, nil
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, errors.InvalidRequest("Invalid form-encoded webhook body: " + err.Error())
+	}
+
+	payload := form.Get("payload")
+	if payload == "" {
+		return nil, errors.InvalidRequest("Form-encoded webhook body has no 'payload' field")
+	}
+	return []byte(payload), nil
+}
+
+// sendWebhookNotification sends a notification to one recipient through the outbound
+// queue, respecting the per-recipient cooldown and the notification's priority
+func (h *Handler) sendWebhookNotification(ctx context.Context, recipient, message string, config WebhookConfig) (string, error) {
+	release, err := h.acquireSend(ctx, recipient, config.Priority)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return h.waClient.SendTextWithOptions(ctx, recipient, message, h.sendOptions(nil))
+}
+
+// SetWebhookContentDedupeWindow sets how long a notification suppresses an identical
+// one to the same recipient; zero disables content deduplication
+func (h *Handler) SetWebhookContentDedupeWindow(window time.Duration) {
+	h.contentDedupe = newContentDeduper(window)
+}
+
+// SetWebhookDuplicateWindow sets how long a delivery ID is remembered; zero disables duplicate detection
+func (h *Handler) SetWebhookDuplicateWindow(window time.Duration) {
+	h.deliveries = newDeliveryTracker(window)
+}
+
+// checkDeliveryAge rejects deliveries older than the configured maximum age.
+// The Date header is preferred; the payload's commit timestamp is the fallback.
+// Deliveries without any usable timestamp are accepted.
+func (h *Handler) checkDeliveryAge(r *http.Request, payload WebhookPayload) *errors.AppError {
+	if h.webhookMaxAge <= 0 {
+		return nil
+	}
+
+	deliveredAt := payload.GetTimestamp()
+	if date, err := http.ParseTime(r.Header.Get("Date")); err == nil {
+		deliveredAt = date
+	}
+	if deliveredAt.IsZero() {
+		return nil
+	}
+
+	if age := time.Since(deliveredAt); age > h.webhookMaxAge {
+		return errors.InvalidRequest(fmt.Sprintf("Webhook delivery is too old (%s, maximum %s)", age.Round(time.Second), h.webhookMaxAge))
+	}
+	return nil
+}
+
+// isDebugRequest reports whether the formatted message should be echoed back.
+// The request must ask for it with ?debug=true and either carry a verified
+// signature (a secret is configured) or the server must run in debug mode.
+func (h *Handler) isDebugRequest(r *http.Request, config WebhookConfig) bool {
+	if r.URL.Query().Get("debug") != "true" {
+		return false
+	}
+	return config.Secret != "" || h.debugMode
+}
+
+// isDryRunRequest reports whether the delivery should be formatted but not sent,
+// requested with ?dry_run=true or an "X-Dry-Run: true" header
+func isDryRunRequest(r *http.Request) bool {
+	return r.URL.Query().Get("dry_run") == "true" || r.Header.Get("X-Dry-Run") == "true"
+}
+
+// authenticateWebhook verifies a delivery using either the shared-secret query
+// parameter or the HMAC signature header, depending on the provider
+func (h *Handler) authenticateWebhook(r *http.Request, body []byte, config WebhookConfig) *errors.AppError {
+	if config.SecretQueryParam != "" {
+		if !h.verifyWebhookSecretParam(r.URL.Query().Get(config.SecretQueryParam), config) {
+			h.log.Warnf("Invalid %s webhook secret", config.Provider)
+			return errors.New(errors.ErrCodeUnauthorized, "Invalid webhook secret")
+		}
+		return nil
+	}
+
+	if config.SecretHeader != "" {
+		provided, ok := strings.CutPrefix(r.Header.Get(config.SecretHeader), config.SecretHeaderPrefix)
+		if !ok {
+			provided = ""
+		}
+		if !h.verifyWebhookSecretParam(provided, config) {
+			h.log.Warnf("Invalid %s webhook token", config.Provider)
+			return errors.New(errors.ErrCodeUnauthorized, "Invalid webhook token")
+		}
+		return nil
+	}
+
+	if h.strictSigs {
+		if name := unexpectedSignatureHeader(r, config.SignatureHeaders); name != "" {
+			h.log.Warnf("%s webhook received with unexpected %s header", config.Provider, name)
+			return errors.New(errors.ErrCodeUnauthorized, fmt.Sprintf("Unexpected %s header", name))
+		}
+	}
+
+	// Get signature from the first accepted header that is present
+	headerSignature, signatureHeader, found := findSignatureHeader(r, config.SignatureHeaders)
+	if !found && config.PayloadSecret != "" {
+		if provided, ok := findPayloadSecret(r, body, config.PayloadSecret); ok {
+			if !h.verifyWebhookSecretParam(provided, config) {
+				h.log.Warnf("Invalid %s webhook payload secret", config.Provider)
+				return errors.New(errors.ErrCodeUnauthorized, "Invalid webhook secret")
+			}
+			return nil
+		}
+	}
+	if !found {
+		h.log.Warnf("%s webhook received without signature header", config.Provider)
+		return errors.New(errors.ErrCodeUnauthorized, fmt.Sprintf("Missing %s header", signatureHeaderNames(config.SignatureHeaders)))
+	}
+
+	// Verify webhook signature
+	if !h.verifyWebhookSignature(body, headerSignature, signatureHeader.Prefix, config) {
+		h.log.Warnf("Invalid %s webhook signature", config.Provider)
+		return errors.New(errors.ErrCodeUnauthorized, "Invalid webhook signature")
+	}
+
+	// In strict mode a spoofed lower-precedence signature can't ride along with a valid one
+	if h.strictSigs && config.Secret != "" {
+		for _, header := range config.SignatureHeaders {
+			value := r.Header.Get(header.Name)
+			if value == "" || header.Name == signatureHeader.Name {
+				continue
+			}
+			if !h.verifyWebhookSignature(body, value, header.Prefix, config) {
+				h.log.Warnf("Invalid %s webhook signature in %s header", config.Provider, header.Name)
+				return errors.New(errors.ErrCodeUnauthorized, "Invalid webhook signature")
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyWebhookSecretParam verifies a shared secret passed as a query parameter or header
+func (h *Handler) verifyWebhookSecretParam(provided string, config WebhookConfig) bool {
+	if config.Secret == "" {
+		// If no secret is configured, skip verification
+		h.log.Warnf("%s webhook secret not configured, skipping secret verification", config.Provider)
+		return true
+	}
+
+	// Compare using constant time comparison to prevent timing attacks
+	return hmac.Equal([]byte(provided), []byte(config.Secret))
+}
+
+// findSignatureHeader returns the value of the first accepted signature header present in the request
+func findSignatureHeader(r *http.Request, headers []SignatureHeader) (string, SignatureHeader, bool) {
+	for _, header := range headers {
+		if value := r.Header.Get(header.Name); value != "" {
+			return value, header, true
+		}
 	}
+	return "", SignatureHeader{}, false
+}
+
+// knownSignatureHeaders are the signature headers trusted by the built-in webhook routes.
+// GitHub's legacy SHA-1 X-Hub-Signature is never checked, so it isn't listed.
+var knownSignatureHeaders = []string{"X-Hub-Signature-256", "X-Gitea-Signature", "X-Gogs-Signature"}
 
-	h.log.Infof("%s webhook notification sent to %s", config.Provider, config.Recipient)
-	h.writeJSON(w, map[string]string{"status": "notification sent"}, http.StatusOK)
+// unexpectedSignatureHeader returns the name of a known signature header present in the
+// request that the route doesn't accept, or ""
+func unexpectedSignatureHeader(r *http.Request, accepted []SignatureHeader) string {
+	for _, name := range knownSignatureHeaders {
+		if r.Header.Get(name) == "" {
+			continue
+		}
+		isAccepted := false
+		for _, header := range accepted {
+			if http.CanonicalHeaderKey(header.Name) == http.CanonicalHeaderKey(name) {
+				isAccepted = true
+				break
+			}
+		}
+		if !isAccepted {
+			return name
+		}
+	}
+	return ""
+}
+
+// findPayloadSecret returns the non-empty string value of a top-level payload field.
+// The value is a credential, so it must never be logged.
+func findPayloadSecret(r *http.Request, body []byte, field string) (string, bool) {
+	payload, appErr := extractWebhookJSON(r, body)
+	if appErr != nil {
+		return "", false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return "", false
+	}
+
+	var secret string
+	if err := json.Unmarshal(fields[field], &secret); err != nil || secret == "" {
+		return "", false
+	}
+	return secret, true
+}
+
+// signatureHeaderNames joins the accepted signature header names for error messages
+func signatureHeaderNames(headers []SignatureHeader) string {
+	names := make([]string, len(headers))
+	for i, header := range headers {
+		names[i] = header.Name
+	}
+	return strings.Join(names, " or ")
 }
 
 // verifyWebhookSignature verifies the HMAC SHA256 signature of the webhook payload
-func (h *Handler) verifyWebhookSignature(payload []byte, headerSignature string, config WebhookConfig) bool {
+func (h *Handler) verifyWebhookSignature(payload []byte, headerSignature, signaturePrefix string, config WebhookConfig) bool {
 	if config.Secret == "" {
 		// If no secret is configured, skip signature verification
 		h.log.Warnf("%s webhook secret not configured, skipping signature verification", config.Provider)
@@ -113,11 +527,11 @@ func (h *Handler) verifyWebhookSignature(payload []byte, headerSignature string,
 
 	// Handle signature prefix (e.g., "sha256=" for GitHub)
 	providedSignature := headerSignature
-	if config.SignaturePrefix != "" {
-		if !strings.HasPrefix(headerSignature, config.SignaturePrefix) {
+	if signaturePrefix != "" {
+		if !strings.HasPrefix(headerSignature, signaturePrefix) {
 			return false
 		}
-		providedSignature = strings.TrimPrefix(headerSignature, config.SignaturePrefix)
+		providedSignature = strings.TrimPrefix(headerSignature, signaturePrefix)
 	}
 
 	// Calculate HMAC SHA256 signature
@@ -129,15 +543,53 @@ func (h *Handler) verifyWebhookSignature(payload []byte, headerSignature string,
 	return hmac.Equal([]byte(providedSignature), []byte(expectedSignature))
 }
 
+// pusherName returns the name shown as the pusher, honoring PUSHER_SOURCE where the payload supports it
+func (h *Handler) pusherName(payload WebhookPayload) string {
+	if p, ok := payload.(pusherSourcePayload); ok {
+		return p.GetPusherNameFrom(h.pusherSource)
+	}
+	return payload.GetPusherName()
+}
+
 // formatWebhookMessage constructs a formatted WhatsApp message from webhook payload
 func (h *Handler) formatWebhookMessage(payload WebhookPayload, provider WebhookProvider) string {
+	// Non-push events have their own format
+	switch event := payload.(type) {
+	case models.JenkinsWebhookPayload:
+		return formatJenkinsMessage(event)
+	case models.AlertmanagerPayload:
+		return h.formatAlertmanagerMessage(event)
+	case models.CustomWebhookPayload:
+		return event.Message
+	case models.GitHubPullRequestPayload:
+		return formatGitHubPullRequestMessage(event)
+	case models.GitHubIssuesPayload:
+		return formatGitHubIssueMessage(event)
+	}
+
+	// Operator-supplied template, falling back to the built-in format if it fails
+	h.webhookMutex.RLock()
+	tmpl := h.messageTemplates[provider]
+	h.webhookMutex.RUnlock()
+	if tmpl != nil {
+		var sb strings.Builder
+		err := tmpl.Execute(&sb, payload)
+		if err == nil {
+			return strings.TrimSpace(sb.String())
+		}
+		h.log.Warnf("Failed to render %s message template, using the built-in format: %v", provider, err)
+	}
+
 	var sb strings.Builder
 
 	// Repository and pusher info
-	sb.WriteString(fmt.Sprintf("🔔 New Push to *%s*\n", payload.GetRepositoryName()))
+	sb.WriteString(fmt.Sprintf("%s New Push to *%s*\n", h.iconForRepo(payload.GetRepositoryName()), payload.GetRepositoryName()))
+	if isForcePush(payload) {
+		sb.WriteString("⚠️ *Force push*: history may have been rewritten\n")
+	}
 	sb.WriteString("\n```")
-	sb.WriteString(fmt.Sprintf("👤 Pusher : %s\n", payload.GetPusherName()))
-	sb.WriteString(fmt.Sprintf("🌿 Branch : %s\n", payload.GetBranch()))
+	sb.WriteString(fmt.Sprintf("👤 Pusher : %s\n", h.pusherName(payload)))
+	sb.WriteString(fmt.Sprintf("🌿 Branch : %s\n", h.displayBranch(payload.GetBranch())))
 	sb.WriteString(fmt.Sprintf("📊 Commits: %d\n", payload.GetCommitCount()))
 	sb.WriteString("```\n")
 
@@ -149,8 +601,24 @@ func (h *Handler) formatWebhookMessage(payload WebhookPayload, provider WebhookP
 		return sb.String()
 	}
 
+	// Hide merge commits unless they're shown
+	hiddenMerges := 0
+	if !h.showMerges {
+		visible := make([]models.CommitInfo, 0, len(commits))
+		for _, commit := range commits {
+			if commit.IsMerge() {
+				hiddenMerges++
+				continue
+			}
+			visible = append(visible, commit)
+		}
+		commits = visible
+	}
+
 	// Add commit details
-	sb.WriteString("*Commits:*\n")
+	if len(commits) > 0 {
+		sb.WriteString("*Commits:*\n")
+	}
 	for i, commit := range commits {
 		// Limit to first 5 commits
 		if i >= 5 {
@@ -159,27 +627,21 @@ func (h *Handler) formatWebhookMessage(payload WebhookPayload, provider WebhookP
 			break
 		}
 
-		// Get short commit hash (first 7 chars)
-		shortHash := commit.ID
-		if len(shortHash) > 7 {
-			shortHash = shortHash[:7]
-		}
+		shortHash := models.ShortHash(commit.ID)
+		message := models.Truncate(60, models.FirstLine(commit.Message))
 
-		// Get first line of commit message
-		message := commit.Message
-		if idx := strings.Index(message, "\n"); idx != -1 {
-			message = message[:idx]
-		}
-		// Truncate long messages
-		if len(message) > 60 {
-			message = message[:57] + "..."
+		if commit.IsMerge() {
+			sb.WriteString(fmt.Sprintf("• `%s` - 🔀 _%s_\n", shortHash, message))
+			continue
 		}
-
 		sb.WriteString(fmt.Sprintf("• `%s` - %s\n", shortHash, message))
 	}
+	if hiddenMerges > 0 {
+		sb.WriteString(fmt.Sprintf("_%d merge commit(s) hidden_\n", hiddenMerges))
+	}
 
-	// Add file change summary (only for GitHub)
-	if provider == ProviderGitHub {
+	// Add file change summary (Gitea and GitHub report changed files per commit)
+	if provider == ProviderGitea || provider == ProviderGitHub {
 		// Add compare URL if available
 		if compareURL := payload.GetCompareURL(); compareURL != "" {
 			sb.WriteString(fmt.Sprintf("\n🔗 View changes: %s", compareURL))
@@ -190,39 +652,31 @@ func (h *Handler) formatWebhookMessage(payload WebhookPayload, provider WebhookP
 		if totalChanges > 0 {
 			sb.WriteString("\n\n*File Changes:*\n")
 
+			// In counts mode the totals go on consecutive lines without file names
+			listFiles := h.fileChanges != models.FileChangesCounts
+			separator := ""
+			if listFiles {
+				separator = "\n"
+			}
+
 			if fileChanges.TotalAdded > 0 {
 				sb.WriteString(fmt.Sprintf("✅ Added: %d\n", fileChanges.TotalAdded))
-				for i, file := range fileChanges.AddedFiles {
-					if i >= 20 {
-						remaining := len(fileChanges.AddedFiles) - 20
-						sb.WriteString(fmt.Sprintf("   _...and %d more_\n", remaining))
-						break
-					}
-					sb.WriteString(fmt.Sprintf("   • %s\n", file))
+				if listFiles {
+					writeFileList(&sb, fileChanges.AddedFiles)
 				}
 			}
 
 			if fileChanges.TotalModified > 0 {
-				sb.WriteString(fmt.Sprintf("\n📝 Modified: %d\n", fileChanges.TotalModified))
-				for i, file := range fileChanges.ModifiedFiles {
-					if i >= 20 {
-						remaining := len(fileChanges.ModifiedFiles) - 20
-						sb.WriteString(fmt.Sprintf("   _...and %d more_\n", remaining))
-						break
-					}
-					sb.WriteString(fmt.Sprintf("   • %s\n", file))
+				sb.WriteString(fmt.Sprintf("%s📝 Modified: %d\n", separator, fileChanges.TotalModified))
+				if listFiles {
+					writeFileList(&sb, fileChanges.ModifiedFiles)
 				}
 			}
 
 			if fileChanges.TotalRemoved > 0 {
-				sb.WriteString(fmt.Sprintf("\n❌ Removed: %d\n", fileChanges.TotalRemoved))
-				for i, file := range fileChanges.RemovedFiles {
-					if i >= 20 {
-						remaining := len(fileChanges.RemovedFiles) - 20
-						sb.WriteString(fmt.Sprintf("   _...and %d more_\n", remaining))
-						break
-					}
-					sb.WriteString(fmt.Sprintf("   • %s\n", file))
+				sb.WriteString(fmt.Sprintf("%s❌ Removed: %d\n", separator, fileChanges.TotalRemoved))
+				if listFiles {
+					writeFileList(&sb, fileChanges.RemovedFiles)
 				}
 			}
 		}
@@ -230,3 +684,15 @@ func (h *Handler) formatWebhookMessage(payload WebhookPayload, provider WebhookP
 
 	return sb.String()
 }
+
+// writeFileList writes up to 20 file names, then a count of the rest
+func writeFileList(sb *strings.Builder, files []string) {
+	const maxFiles = 20
+	for i, file := range files {
+		if i >= maxFiles {
+			sb.WriteString(fmt.Sprintf("   _...and %d more_\n", len(files)-maxFiles))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("   • %s\n", file))
+	}
+}
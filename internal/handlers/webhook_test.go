@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testPushPayload = `{
+	"ref": "refs/heads/main",
+	"commits": [{"id": "0123456789abcdef", "message": "Fix the build"}],
+	"repository": {"full_name": "owner/repo"},
+	"pusher": {"name": "octocat"}
+}`
+
+// sign returns the hex HMAC SHA256 signature of body
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookMixedSignatureHeaders(t *testing.T) {
+	spoofed := sign("wrong-secret", testPushPayload)
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		want    int // Status with strict mode off
+		strict  int // Status with strict mode on
+	}{
+		{
+			name: "gitea: valid X-Hub-Signature-256 with spoofed X-Gitea-Signature",
+			path: "/webhook/gitea",
+			headers: map[string]string{
+				"X-Hub-Signature-256": "sha256=" + sign(testGiteaSecret, testPushPayload),
+				"X-Gitea-Signature":   spoofed,
+			},
+			// X-Gitea-Signature takes precedence on the Gitea route, so the spoof is always checked
+			want:   http.StatusUnauthorized,
+			strict: http.StatusUnauthorized,
+		},
+		{
+			name: "github: valid X-Hub-Signature-256 with spoofed X-Gitea-Signature",
+			path: "/webhook/github",
+			headers: map[string]string{
+				"X-Hub-Signature-256": "sha256=" + sign(testGitHubSecret, testPushPayload),
+				"X-Gitea-Signature":   spoofed,
+			},
+			// The GitHub route ignores X-Gitea-Signature unless strict mode rejects it
+			want:   http.StatusOK,
+			strict: http.StatusUnauthorized,
+		},
+		{
+			name: "gitea: valid X-Gitea-Signature with invalid X-Hub-Signature-256",
+			path: "/webhook/gitea",
+			headers: map[string]string{
+				"X-Gitea-Signature":   sign(testGiteaSecret, testPushPayload),
+				"X-Hub-Signature-256": "sha256=" + spoofed,
+			},
+			want:   http.StatusOK,
+			strict: http.StatusUnauthorized,
+		},
+		{
+			name: "gitea: valid X-Gitea-Signature with invalid X-Gogs-Signature",
+			path: "/webhook/gitea",
+			headers: map[string]string{
+				"X-Gitea-Signature": sign(testGiteaSecret, testPushPayload),
+				"X-Gogs-Signature":  spoofed,
+			},
+			want:   http.StatusOK,
+			strict: http.StatusUnauthorized,
+		},
+		{
+			name: "github: foreign X-Gitea-Signature only",
+			path: "/webhook/github",
+			headers: map[string]string{
+				"X-Gitea-Signature": sign(testGitHubSecret, testPushPayload),
+			},
+			want:   http.StatusUnauthorized,
+			strict: http.StatusUnauthorized,
+		},
+		{
+			name: "gitea: valid X-Hub-Signature-256 only",
+			path: "/webhook/gitea",
+			headers: map[string]string{
+				"X-Hub-Signature-256": "sha256=" + sign(testGiteaSecret, testPushPayload),
+			},
+			want:   http.StatusOK,
+			strict: http.StatusOK,
+		},
+		{
+			name: "github: valid X-Hub-Signature-256 only",
+			path: "/webhook/github",
+			headers: map[string]string{
+				"X-Hub-Signature-256": "sha256=" + sign(testGitHubSecret, testPushPayload),
+			},
+			want:   http.StatusOK,
+			strict: http.StatusOK,
+		},
+	}
+
+	for _, strict := range []bool{false, true} {
+		for _, tt := range tests {
+			name := tt.name
+			want := tt.want
+			if strict {
+				name = "strict/" + name
+				want = tt.strict
+			}
+
+			t.Run(name, func(t *testing.T) {
+				h := newTestHandler(nil)
+				h.SetStrictSignatureHeaders(strict)
+
+				// Dry runs stop before WhatsApp, so accepted deliveries need no client
+				req := httptest.NewRequest(http.MethodPost, tt.path+"?dry_run=true", strings.NewReader(testPushPayload))
+				req.Header.Set("Content-Type", "application/json")
+				for name, value := range tt.headers {
+					req.Header.Set(name, value)
+				}
+				rec := httptest.NewRecorder()
+
+				if tt.path == "/webhook/gitea" {
+					h.GiteaWebhook(rec, req)
+				} else {
+					h.GitHubWebhook(rec, req)
+				}
+
+				if rec.Code != want {
+					t.Fatalf("status = %d, want %d (body: %s)", rec.Code, want, rec.Body.String())
+				}
+				if want == http.StatusOK && !strings.Contains(rec.Body.String(), `"status":"dry run"`) {
+					t.Errorf("accepted delivery wasn't processed as a dry run: %s", rec.Body.String())
+				}
+			})
+		}
+	}
+}
@@ -1,23 +1,38 @@
 package handlers
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/nahidhasan98/whatsapp-notifier/internal/app"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
 )
 
-// GetContacts handles requests to get all contacts
+// GetContacts handles requests to list contacts, with optional search and pagination
 func (h *Handler) GetContacts(w http.ResponseWriter, r *http.Request) {
 	if h.waClient == nil {
 		h.writeAppError(w, errors.ClientNotConnected())
 		return
 	}
 
+	limit, offset, appErr := parsePagination(r)
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+	search := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("search")))
+
 	ctx := r.Context()
 	contacts, err := h.waClient.GetContacts(ctx)
 	if err != nil {
@@ -26,16 +41,277 @@ func (h *Handler) GetContacts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, contacts, http.StatusOK)
+	// Filter by push name, business name or JID number. Only JIDs are collected so
+	// large stores aren't copied; contacts are built while the page is written.
+	matched := make([]types.JID, 0, len(contacts))
+	for jid, contact := range contacts {
+		if search != "" &&
+			!strings.Contains(strings.ToLower(contact.PushName), search) &&
+			!strings.Contains(strings.ToLower(contact.BusinessName), search) &&
+			!strings.Contains(jid.User, search) {
+			continue
+		}
+		matched = append(matched, jid)
+	}
+
+	// Sort by push name, then JID, so pages are stable
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := strings.ToLower(contacts[matched[i]].PushName), strings.ToLower(contacts[matched[j]].PushName)
+		if a != b {
+			return a < b
+		}
+		return matched[i].String() < matched[j].String()
+	})
+
+	start, end := pageBounds(len(matched), limit, offset)
+	prefix := fmt.Sprintf(`{"total":%d,"limit":%d,"offset":%d,"contacts":`, len(matched), limit, offset)
+	h.writeContactsJSON(w, prefix, "}", matched[start:end], contacts)
+}
+
+// GetContact handles requests to look up a single contact by JID or phone number
+func (h *Handler) GetContact(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	jid, appErr := h.validator.NormalizeJID(r.PathValue("jid"))
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	contact, err := h.waClient.GetContact(r.Context(), jid)
+	if err != nil {
+		if stderrors.Is(err, app.ErrContactNotFound) {
+			h.writeAppError(w, errors.NotFound("Contact not found: "+jid))
+			return
+		}
+		h.log.Error("Failed to get contact", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+
+	h.writeJSON(w, contactInfo(jid, contact), http.StatusOK)
+}
+
+// CheckNumbers handles requests to check whether phone numbers are registered on WhatsApp
+func (h *Handler) CheckNumbers(w http.ResponseWriter, r *http.Request) {
+	const maxNumbers = 50
+
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	var numbers []string
+	if err := json.NewDecoder(r.Body).Decode(&numbers); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body (expected a JSON array of phone numbers): "+err.Error()))
+		return
+	}
+	if len(numbers) == 0 || len(numbers) > maxNumbers {
+		h.writeAppError(w, errors.ValidationError(fmt.Sprintf("Provide between 1 and %d phone numbers", maxNumbers)))
+		return
+	}
+
+	// Normalize numbers, remembering the form each was given in
+	normalized := make([]string, len(numbers))
+	for i, number := range numbers {
+		phone, appErr := h.validator.NormalizePhoneNumber(number)
+		if appErr != nil {
+			h.writeAppError(w, errors.ValidationError(fmt.Sprintf("Invalid phone number '%s' (expected an international number with a valid country code)", number)))
+			return
+		}
+		normalized[i] = phone
+	}
+
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
+	statuses, err := h.waClient.IsOnWhatsApp(r.Context(), normalized)
+	if err != nil {
+		if stderrors.Is(err, app.ErrRateLimited) {
+			h.log.Warnf("WhatsApp rate limited a number check: %v", err)
+			h.writeAppError(w, errors.New(errors.ErrCodeTooManyRequests, "WhatsApp rate limit reached, please retry later"))
+			return
+		}
+		h.log.Error("Failed to check numbers", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+
+	response := make(map[string]models.NumberCheckResult, len(numbers))
+	for i, number := range numbers {
+		status := statuses[normalized[i]]
+		response[number] = models.NumberCheckResult{Registered: status.Registered, JID: status.JID}
+	}
+
+	h.writeJSON(w, response, http.StatusOK)
 }
 
-// GetGroups handles requests to get all groups
+// ExportContacts handles requests to export all contacts as JSON (default) or CSV
+func (h *Handler) ExportContacts(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		h.writeAppError(w, errors.InvalidRequest("Unsupported export format: "+format+" (must be json or csv)"))
+		return
+	}
+
+	ctx := r.Context()
+	contacts, err := h.waClient.GetContacts(ctx)
+	if err != nil {
+		h.log.Error("Failed to get contacts", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+
+	// Sort by JID for stable output
+	jids := make([]types.JID, 0, len(contacts))
+	for jid := range contacts {
+		jids = append(jids, jid)
+	}
+	sort.Slice(jids, func(i, j int) bool { return jids[i].String() < jids[j].String() })
+
+	if format == "json" {
+		h.writeContactsJSON(w, "", "", jids, contacts)
+		return
+	}
+
+	h.writeContactsCSV(w, jids, contacts)
+}
+
+// contactInfo converts a stored contact to its API representation
+func contactInfo(jid string, contact types.ContactInfo) models.ContactInfo {
+	return models.ContactInfo{
+		JID:          jid,
+		PushName:     contact.PushName,
+		BusinessName: contact.BusinessName,
+		FirstName:    contact.FirstName,
+		FullName:     contact.FullName,
+	}
+}
+
+// writeContactsJSON streams contacts as a JSON array, encoding one contact at a time and
+// flushing in chunks so large lists aren't buffered. prefix and suffix wrap the array,
+// e.g. to embed it in an object.
+func (h *Handler) writeContactsJSON(w http.ResponseWriter, prefix, suffix string, jids []types.JID, contacts map[types.JID]types.ContactInfo) {
+	const flushEvery = 500
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+
+	write := func() error {
+		if _, err := bw.WriteString(prefix + "["); err != nil {
+			return err
+		}
+		for i, jid := range jids {
+			if i > 0 {
+				if err := bw.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if err := encoder.Encode(contactInfo(jid.String(), contacts[jid])); err != nil {
+				return err
+			}
+
+			if (i+1)%flushEvery == 0 {
+				if err := bw.Flush(); err != nil {
+					return err
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			}
+		}
+		if _, err := bw.WriteString("]" + suffix + "\n"); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	if err := write(); err != nil {
+		if isClientGone(err) {
+			h.log.Debugf("Client went away while streaming contacts: %v", err)
+			return
+		}
+		h.log.Error("Failed to stream contacts", err)
+	}
+}
+
+// writeContactsCSV streams contacts as CSV, flushing in chunks so large lists aren't buffered
+func (h *Handler) writeContactsCSV(w http.ResponseWriter, jids []types.JID, contacts map[types.JID]types.ContactInfo) {
+	const flushEvery = 500
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="contacts.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"jid", "push_name", "full_name", "business_name"}); err != nil {
+		h.log.Error("Failed to write CSV header", err)
+		return
+	}
+
+	for i, jid := range jids {
+		contact := contacts[jid]
+		if err := writer.Write([]string{jid.String(), contact.PushName, contact.FullName, contact.BusinessName}); err != nil {
+			h.log.Error("Failed to write CSV row", err)
+			return
+		}
+
+		if (i+1)%flushEvery == 0 {
+			writer.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		h.log.Error("Failed to flush CSV response", err)
+	}
+}
+
+// GetGroups handles requests to list joined groups, with optional search, filtering and pagination
 func (h *Handler) GetGroups(w http.ResponseWriter, r *http.Request) {
 	if h.waClient == nil {
 		h.writeAppError(w, errors.ClientNotConnected())
 		return
 	}
 
+	limit, offset, appErr := parsePagination(r)
+	if appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+	query := r.URL.Query()
+	search := strings.ToLower(strings.TrimSpace(query.Get("search")))
+	announceOnly := query.Get("announce_only") == "true"
+
 	ctx := r.Context()
 	groups, err := h.waClient.GetJoinedGroups(ctx)
 	if err != nil {
@@ -44,7 +320,103 @@ func (h *Handler) GetGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, groups, http.StatusOK)
+	// Filter by name/topic and announcement mode
+	matched := make([]models.GroupInfo, 0, len(groups))
+	for _, group := range groups {
+		if announceOnly && !group.IsAnnounce {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(group.Name), search) &&
+			!strings.Contains(strings.ToLower(group.Topic), search) {
+			continue
+		}
+
+		info := models.GroupInfo{
+			JID:         group.JID.String(),
+			Name:        group.Name,
+			Topic:       group.Topic,
+			IsAnnounce:  group.IsAnnounce,
+			IsLocked:    group.IsLocked,
+			IsEphemeral: group.IsEphemeral,
+		}
+		if !group.GroupCreated.IsZero() {
+			info.CreatedAt = group.GroupCreated.Unix()
+		}
+		matched = append(matched, info)
+	}
+
+	// Sort by name, then JID, so pages are stable
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := strings.ToLower(matched[i].Name), strings.ToLower(matched[j].Name)
+		if a != b {
+			return a < b
+		}
+		return matched[i].JID < matched[j].JID
+	})
+
+	start, end := pageBounds(len(matched), limit, offset)
+	h.writeJSON(w, &models.GroupListResponse{
+		Total:  len(matched),
+		Limit:  limit,
+		Offset: offset,
+		Groups: matched[start:end],
+	}, http.StatusOK)
+}
+
+// GetGroupMembers handles requests to list the members of a joined group
+func (h *Handler) GetGroupMembers(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	jid := strings.TrimSpace(r.PathValue("jid"))
+	if !h.validator.IsGroupJID(jid) {
+		h.writeAppError(w, errors.ValidationError("Group JID required (e.g. 120363025343298765@g.us)"))
+		return
+	}
+
+	ctx := r.Context()
+	participants, err := h.waClient.GetGroupMembers(ctx, jid)
+	if err != nil {
+		if stderrors.Is(err, app.ErrNotGroupMember) {
+			h.writeAppError(w, errors.NotFound("Not a member of group "+jid))
+			return
+		}
+		h.log.Error("Failed to get group members", err)
+		h.writeAppError(w, errors.InternalError(err))
+		return
+	}
+
+	// Push names come from the contact store; members never seen have none
+	contacts, err := h.waClient.GetContacts(ctx)
+	if err != nil {
+		h.log.Warnf("Failed to get contacts for group member names: %v", err)
+	}
+
+	members := make([]models.GroupMember, 0, len(participants))
+	for _, participant := range participants {
+		member := models.GroupMember{
+			JID:          participant.JID.String(),
+			IsAdmin:      participant.IsAdmin,
+			IsSuperAdmin: participant.IsSuperAdmin,
+			PushName:     contacts[participant.JID].PushName,
+		}
+		if !participant.PhoneNumber.IsEmpty() && participant.PhoneNumber != participant.JID {
+			member.PhoneNumber = participant.PhoneNumber.String()
+			if member.PushName == "" {
+				member.PushName = contacts[participant.PhoneNumber].PushName
+			}
+		}
+		members = append(members, member)
+	}
+
+	h.writeJSON(w, &models.GroupMembersResponse{
+		JID:     jid,
+		Total:   len(members),
+		Members: members,
+	}, http.StatusOK)
 }
 
 // SendMessage handles requests to send a message
@@ -61,33 +433,97 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Resolve "@last" to the most recently messaged recipient
+	if req.To == models.LastRecipient {
+		lastTo, ok := h.waClient.LastRecipient()
+		if !ok {
+			h.writeAppError(w, errors.ValidationError("No previous recipient to resolve '@last' to; send to a JID first"))
+			return
+		}
+		req.To = lastTo
+	}
+
+	// Accept phone numbers and device JIDs; groups, LIDs and valid JIDs pass through unchanged
+	if strings.TrimSpace(req.To) != "" {
+		normalized, appErr := h.validator.NormalizeJID(req.To)
+		if appErr != nil {
+			h.writeAppError(w, appErr)
+			return
+		}
+		req.To = normalized
+	}
+
 	// Validate request
 	if appErr := h.validator.ValidateSendMessageRequest(&req); appErr != nil {
 		h.writeAppError(w, appErr)
 		return
 	}
 
-	// Sanitize message
-	req.Message = h.validator.SanitizeMessage(req.Message)
+	// Sanitize message unless a trusted caller asked for it verbatim
+	if req.Raw {
+		if !h.canSendRaw(r) {
+			h.writeAppError(w, errors.New(errors.ErrCodeForbidden, "API key is not allowed to send raw messages"))
+			return
+		}
+	} else {
+		req.Message = h.validator.SanitizeMessage(req.Message)
+	}
+	req.Priority = req.Priority.OrDefault()
+
+	// Append a signed link the recipient opens to confirm receipt
+	var confirmation confirmationClaims
+	if req.RequestConfirmation {
+		if h.confirmations == nil {
+			h.writeAppError(w, errors.ValidationError("Confirmation links are not enabled (set CONFIRM_LINK_SECRET and CONFIRM_LINK_BASE_URL)"))
+			return
+		}
+		claims, link, err := h.confirmations.issue(req.To)
+		if err != nil {
+			h.writeAppError(w, errors.InternalError(err))
+			return
+		}
+		confirmation = claims
+		req.Message += "\n\n✅ Tap to confirm receipt: " + link
 
-	// Ensure client is connected
-	if !h.waClient.IsConnected() {
-		ctx := r.Context()
-		if err := h.waClient.EnsureConnected(ctx); err != nil {
-			h.log.Error("Failed to connect client", err)
-			h.writeAppError(w, errors.ConnectionFailed(err))
+		// The link was added after validation, so the message must still fit with it
+		if appErr := h.validator.CheckMessageLength(req.Message, req.OmitFooter); appErr != nil {
+			appErr.Message += "; the confirmation link counts toward the limit"
+			h.writeAppError(w, appErr)
 			return
 		}
 	}
 
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
 	// Check if it's a LID - warn user that it might not work
 	if strings.HasSuffix(req.To, "@lid") {
 		h.log.Infof("LID detected: %s. Attempting to send directly (may fail if not messageable)", req.To)
 	}
 
-	// Send message
+	// Queue the send behind the per-recipient cooldown and higher-priority sends
 	ctx := r.Context()
-	if err := h.waClient.SendText(ctx, req.To, req.Message); err != nil {
+	release, err := h.acquireSend(ctx, req.To, req.Priority)
+	if err != nil {
+		h.writeAppError(w, queueFailed(err))
+		return
+	}
+	defer release()
+
+	// Send message
+	opts := h.sendOptions(req.Forwarded)
+	opts.QuotedMessageID = req.QuotedMessageID
+	opts.QuotedJID = req.QuotedJID
+	opts.DisappearingTimer = req.DisappearingTimer
+	opts.SimulateTyping = req.SimulateTyping
+	opts.OmitFooter = req.OmitFooter
+
+	messageID, err := h.waClient.SendTextWithOptions(ctx, req.To, req.Message, opts)
+	if err != nil {
 		h.log.Error("Failed to send message", err)
 
 		// Provide helpful error message for LIDs
@@ -97,14 +533,268 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		h.writeAppError(w, errors.MessageSendFailed(err))
+		h.writeAppError(w, sendFailed(err, req.To))
+		return
+	}
+
+	response := &models.SendMessageResponse{
+		Status:    "sent",
+		To:        req.To,
+		MessageID: messageID,
+		Priority:  req.Priority,
+		Timestamp: time.Now().Unix(),
+	}
+	if confirmation.ID != "" {
+		h.confirmations.record(confirmation, messageID)
+		response.ConfirmationID = confirmation.ID
+	}
+
+	// Expose the message ID as a header for clients and proxies that don't parse the body
+	if response.MessageID != "" {
+		w.Header().Set("X-Message-ID", response.MessageID)
+	}
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// SendGroupMessage handles requests to send a group message that @mentions members
+func (h *Handler) SendGroupMessage(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	var req models.SendGroupMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	if appErr := h.validator.ValidateSendGroupMessageRequest(&req); appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	req.Message = h.validator.SanitizeMessage(req.Message)
+	req.Priority = req.Priority.OrDefault()
+
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
+	// Queue the send behind the per-recipient cooldown and higher-priority sends
+	ctx := r.Context()
+	release, err := h.acquireSend(ctx, req.To, req.Priority)
+	if err != nil {
+		h.writeAppError(w, queueFailed(err))
+		return
+	}
+	defer release()
+
+	opts := h.sendOptions(nil)
+	opts.OmitFooter = req.OmitFooter
+
+	messageID, err := h.waClient.SendGroupText(ctx, req.To, req.Message, req.Mentions, opts)
+	if err != nil {
+		h.log.Error("Failed to send group message", err)
+		h.writeAppError(w, sendFailed(err, req.To))
 		return
 	}
 
 	response := &models.SendMessageResponse{
 		Status:    "sent",
 		To:        req.To,
+		MessageID: messageID,
+		Priority:  req.Priority,
 		Timestamp: time.Now().Unix(),
 	}
-	h.writeJSON(w, response, http.StatusAccepted)
+	if response.MessageID != "" {
+		w.Header().Set("X-Message-ID", response.MessageID)
+	}
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// SendReaction handles requests to react to a message with an emoji
+func (h *Handler) SendReaction(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.writeAppError(w, errors.InvalidRequest("Method not allowed, use POST"))
+		return
+	}
+
+	var req models.SendReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeAppError(w, errors.InvalidRequest("Invalid request body: "+err.Error()))
+		return
+	}
+
+	if appErr := h.validator.ValidateSendReactionRequest(&req); appErr != nil {
+		h.writeAppError(w, appErr)
+		return
+	}
+
+	// Ensure client is connected and past its ready grace period
+	if err := h.waClient.EnsureConnected(r.Context()); err != nil {
+		h.log.Error("Failed to connect client", err)
+		h.writeAppError(w, errors.ConnectionFailed(err))
+		return
+	}
+
+	// Queue the reaction behind higher-priority sends
+	release, err := h.sendQueue.join(r.Context(), models.PriorityNormal)
+	if err != nil {
+		h.writeAppError(w, queueFailed(err))
+		return
+	}
+	defer release()
+
+	if err := h.waClient.SendReaction(r.Context(), req.To, req.MessageID, req.Sender, req.Emoji); err != nil {
+		h.log.Error("Failed to send reaction", err)
+		h.writeAppError(w, sendFailed(err, req.To))
+		return
+	}
+
+	response := &models.SendMessageResponse{
+		Status:    "sent",
+		To:        req.To,
+		Timestamp: time.Now().Unix(),
+	}
+	h.writeJSON(w, response, h.sendStatus)
+}
+
+// GetOutgoingMessages handles requests to list recently sent messages and their delivery status
+func (h *Handler) GetOutgoingMessages(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	query := r.URL.Query()
+
+	// Parse 'since' as a Unix timestamp (seconds)
+	var since time.Time
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		sinceUnix, err := strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			h.writeAppError(w, errors.ValidationError("'since' must be a Unix timestamp in seconds"))
+			return
+		}
+		since = time.Unix(sinceUnix, 0)
+	}
+
+	status := query.Get("status")
+	if status != "" && !app.IsValidMessageStatus(status) {
+		h.writeAppError(w, errors.ValidationError("Invalid status (must be one of: sent, delivered, read, played)"))
+		return
+	}
+
+	messages := h.waClient.Outgoing.List(since, app.MessageStatus(status))
+
+	response := make([]models.OutgoingMessageInfo, len(messages))
+	for i, msg := range messages {
+		response[i] = models.OutgoingMessageInfo{
+			ID:        msg.ID,
+			To:        msg.To,
+			Status:    string(msg.Status),
+			SentAt:    msg.SentAt.Unix(),
+			UpdatedAt: msg.UpdatedAt.Unix(),
+		}
+	}
+
+	h.writeJSON(w, response, http.StatusOK)
+}
+
+// GetMessageStatus handles requests for the delivery status of a single sent message
+func (h *Handler) GetMessageStatus(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	msg, ok := h.waClient.Outgoing.Get(r.PathValue("id"))
+	if !ok {
+		h.writeAppError(w, errors.NotFound("Message not found or no longer tracked: "+r.PathValue("id")))
+		return
+	}
+
+	h.writeJSON(w, &models.OutgoingMessageInfo{
+		ID:        msg.ID,
+		To:        msg.To,
+		Status:    string(msg.Status),
+		SentAt:    msg.SentAt.Unix(),
+		UpdatedAt: msg.UpdatedAt.Unix(),
+	}, http.StatusOK)
+}
+
+// GetConnectionHistory handles requests to list recent WhatsApp connection state transitions, newest first
+func (h *Handler) GetConnectionHistory(w http.ResponseWriter, r *http.Request) {
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+
+	transitions := h.waClient.ConnectionHistory.List()
+
+	response := make([]models.ConnectionTransitionInfo, len(transitions))
+	for i, transition := range transitions {
+		response[i] = models.ConnectionTransitionInfo{
+			State:     transition.State,
+			Timestamp: transition.Timestamp.Unix(),
+			Reason:    transition.Reason,
+		}
+	}
+
+	h.writeJSON(w, response, http.StatusOK)
+}
+
+// GetMessageLog handles requests to read the most recent entries of the outbound message audit log
+func (h *Handler) GetMessageLog(w http.ResponseWriter, r *http.Request) {
+	const defaultLimit, maxLimit = 50, 1000
+
+	if h.waClient == nil {
+		h.writeAppError(w, errors.ClientNotConnected())
+		return
+	}
+	if h.waClient.MessageLog == nil {
+		h.writeAppError(w, errors.NotFound("Message audit log is not available"))
+		return
+	}
+
+	limit := defaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxLimit {
+			h.writeAppError(w, errors.ValidationError(fmt.Sprintf("'limit' must be a number between 1 and %d", maxLimit)))
+			return
+		}
+		limit = parsed
+	}
+
+	messages, err := h.waClient.MessageLog.Recent(r.Context(), limit)
+	if err != nil {
+		h.log.Error("Failed to read message audit log", err)
+		h.writeAppError(w, errors.DatabaseError(err))
+		return
+	}
+
+	response := make([]models.SentMessageLogEntry, len(messages))
+	for i, msg := range messages {
+		response[i] = models.SentMessageLogEntry{
+			ID:          msg.ID,
+			MessageID:   msg.MessageID,
+			To:          msg.To,
+			BodyPreview: msg.BodyPreview,
+			Status:      msg.Status,
+			Error:       msg.Error,
+			Timestamp:   msg.Timestamp.Unix(),
+		}
+	}
+
+	h.writeJSON(w, response, http.StatusOK)
 }
@@ -11,11 +11,12 @@ import (
 // Logger wraps zerolog.Logger
 type Logger struct {
 	logger  zerolog.Logger
-	logFile *os.File // Keep reference to close on cleanup
+	logFile io.WriteCloser // Keep reference to close on cleanup; nil for child loggers, which don't own it
 }
 
-// New creates a new logger instance
-func New(level, format, logFilePath string) *Logger {
+// New creates a new logger instance. When logFilePath is set, the file is rotated
+// according to rotation.
+func New(level, format, logFilePath string, rotation Rotation) *Logger {
 	// Set log level
 	logLevel, err := zerolog.ParseLevel(level)
 	if err != nil {
@@ -24,9 +25,9 @@ func New(level, format, logFilePath string) *Logger {
 	zerolog.SetGlobalLevel(logLevel)
 
 	// Open log file if path is provided
-	var logFile *os.File
+	var logFile io.WriteCloser
 	if logFilePath != "" {
-		logFile = openLogFile(logFilePath)
+		logFile = openLogFile(logFilePath, rotation)
 	}
 
 	// Create output writer
@@ -41,8 +42,8 @@ func New(level, format, logFilePath string) *Logger {
 	}
 }
 
-// openLogFile creates log directory and opens the log file
-func openLogFile(logFilePath string) *os.File {
+// openLogFile creates log directory and opens the rotating log file
+func openLogFile(logFilePath string, rotation Rotation) io.WriteCloser {
 	// Create directory if it doesn't exist
 	logDir := filepath.Dir(logFilePath)
 	if err := os.MkdirAll(logDir, 0755); err != nil {
@@ -53,7 +54,7 @@ func openLogFile(logFilePath string) *os.File {
 	}
 
 	// Open log file
-	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	logFile, err := openRotatingFile(logFilePath, rotation)
 	if err != nil {
 		// If file opening fails, log to stdout
 		tempLogger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
@@ -65,7 +66,7 @@ func openLogFile(logFilePath string) *os.File {
 }
 
 // createOutputWriter creates the appropriate output writer based on format and log file
-func createOutputWriter(format string, logFile *os.File) io.Writer {
+func createOutputWriter(format string, logFile io.WriteCloser) io.Writer {
 	// Determine writers
 	var fileWriter, consoleWriter io.Writer
 
@@ -87,7 +88,8 @@ func createOutputWriter(format string, logFile *os.File) io.Writer {
 	return consoleWriter
 }
 
-// Close closes the log file if it was opened
+// Close closes the log file if it was opened. Child loggers created with With
+// share the parent's file, so it is only closed through the parent.
 func (l *Logger) Close() error {
 	if l.logFile != nil {
 		return l.logFile.Close()
@@ -140,7 +142,9 @@ func (l *Logger) Fatal(msg string, err error) {
 	l.logger.Fatal().Err(err).Msg(msg)
 }
 
-// With creates a child logger with additional fields
+// With creates a child logger with additional fields. The child writes to the
+// same outputs as the parent, log file included; the zerolog context carries the
+// writer, so only the parent holds the file for closing.
 func (l *Logger) With(key string, value interface{}) *Logger {
 	return &Logger{
 		logger: l.logger.With().Interface(key, value).Logger(),
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp inserted into rotated file names; it sorts chronologically
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Rotation configures size-based log file rotation
+type Rotation struct {
+	MaxSizeMB  int // Rotate once the file would exceed this size (0 disables rotation)
+	MaxBackups int // Rotated files to keep (0 keeps all)
+	MaxAgeDays int // Delete rotated files older than this many days (0 keeps them regardless of age)
+}
+
+// rotatingFile is an append-only log file that is renamed to a timestamped
+// backup and reopened once it reaches its maximum size
+type rotatingFile struct {
+	path     string
+	rotation Rotation
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+// openRotatingFile opens (or creates) the log file for appending
+func openRotatingFile(path string, rotation Rotation) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, rotation: rotation}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// open opens the log file, continuing from its current size
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// Write appends to the log file, rotating it first if the write would exceed the maximum size
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	maxSize := int64(rf.rotation.MaxSizeMB) * 1024 * 1024
+	if maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate renames the current file to a timestamped backup, reopens the log file
+// and removes backups beyond the configured count and age
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(rf.path, rf.backupName(time.Now())); err != nil {
+		return err
+	}
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	rf.pruneBackups()
+	return nil
+}
+
+// backupName returns the rotated file name for a time, e.g. app-2024-01-02T15-04-05.000.log
+func (rf *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(rf.path)
+	base := strings.TrimSuffix(rf.path, ext)
+	return base + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// pruneBackups deletes the oldest backups beyond MaxBackups and any older than MaxAgeDays
+func (rf *rotatingFile) pruneBackups() {
+	if rf.rotation.MaxBackups <= 0 && rf.rotation.MaxAgeDays <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(rf.path)
+	prefix := filepath.Base(strings.TrimSuffix(rf.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(rf.path))
+	if err != nil {
+		return
+	}
+
+	// Collect backups with their rotation times, newest first
+	type backup struct {
+		path      string
+		rotatedAt time.Time
+	}
+	backups := make([]backup, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		rotatedAt, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(rf.path), name), rotatedAt: rotatedAt})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].rotatedAt.After(backups[j].rotatedAt) })
+
+	cutoff := time.Now().AddDate(0, 0, -rf.rotation.MaxAgeDays)
+	for i, b := range backups {
+		tooMany := rf.rotation.MaxBackups > 0 && i >= rf.rotation.MaxBackups
+		tooOld := rf.rotation.MaxAgeDays > 0 && b.rotatedAt.Before(cutoff)
+		if tooMany || tooOld {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+// Close closes the log file
+func (rf *rotatingFile) Close() error {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+	return rf.file.Close()
+}
+
+// OpenRotatingFile opens (or creates) a file for appending that rotates like the
+// log file, creating its directory if needed
+func OpenRotatingFile(path string, rotation Rotation) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return openRotatingFile(path, rotation)
+}
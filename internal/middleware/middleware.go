@@ -2,11 +2,14 @@ package middleware
 
 import (
 	"crypto/subtle"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/logger"
 )
 
@@ -14,17 +17,24 @@ import (
 type Middleware struct {
 	log         *logger.Logger
 	rateLimiter *RateLimiter
-	apiKeys     map[string]bool // Valid API keys
+	apiKeys     map[string]bool // Valid API keys, replaced on configuration reload
+	keysMutex   sync.RWMutex    // Guards apiKeys
+
+	apiKeyHeaderOnly bool // Reject API keys passed in the query string
 }
 
-// RateLimiter implements a simple rate limiter using token bucket algorithm
+// RateLimiter implements a simple rate limiter using token bucket algorithm.
+// Clients are keyed by IP ("ip:" prefix) or API key ("key:" prefix).
 type RateLimiter struct {
 	clients map[string]*ClientBucket
 	mutex   sync.RWMutex
 
 	// Rate limiting configuration
-	requestsPerMinute int
+	requestsPerMinute int // Per client IP in each window
+	requestsPerKey    int // Per API key in each window; 0 limits API key requests by IP
 	windowSize        time.Duration
+
+	stop chan struct{} // Closed to stop the stale bucket sweeper
 }
 
 // ClientBucket represents a rate limit bucket for a specific client
@@ -34,25 +44,45 @@ type ClientBucket struct {
 	mutex      sync.Mutex
 }
 
-// New creates a new middleware instance
-func New(log *logger.Logger) *Middleware {
+// New creates a new middleware instance. A positive rateLimit.PerKey rate limits requests
+// carrying a valid API key per key instead of per client IP.
+func New(log *logger.Logger, rateLimit config.RateLimitConfig) *Middleware {
+	rateLimiter := &RateLimiter{
+		clients:           make(map[string]*ClientBucket),
+		requestsPerMinute: rateLimit.RequestsPerMinute,
+		requestsPerKey:    rateLimit.PerKey,
+		windowSize:        rateLimit.WindowSize,
+		stop:              make(chan struct{}),
+	}
+	go rateLimiter.sweep(rateLimit.SweepInterval)
+
 	return &Middleware{
-		log: log,
-		rateLimiter: &RateLimiter{
-			clients:           make(map[string]*ClientBucket),
-			requestsPerMinute: 60, // Default: 60 requests per minute
-			windowSize:        time.Minute,
-		},
-		apiKeys: make(map[string]bool),
+		log:         log,
+		rateLimiter: rateLimiter,
+		apiKeys:     make(map[string]bool),
 	}
 }
 
+// Close stops the middleware's background work
+func (m *Middleware) Close() {
+	close(m.rateLimiter.stop)
+}
+
 // SetAPIKeys sets the valid API keys for authentication
 func (m *Middleware) SetAPIKeys(keys []string) {
-	m.apiKeys = make(map[string]bool)
+	apiKeys := make(map[string]bool)
 	for _, key := range keys {
-		m.apiKeys[key] = true
+		apiKeys[key] = true
 	}
+
+	m.keysMutex.Lock()
+	defer m.keysMutex.Unlock()
+	m.apiKeys = apiKeys
+}
+
+// SetAPIKeyHeaderOnly controls whether API keys are only accepted in the X-API-Key header
+func (m *Middleware) SetAPIKeyHeaderOnly(enabled bool) {
+	m.apiKeyHeaderOnly = enabled
 }
 
 // Logging logs HTTP requests with detailed information
@@ -67,7 +97,8 @@ func (m *Middleware) Logging(next http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
-		m.log.With("method", r.Method).
+		m.log.With("request_id", RequestIDFromContext(r.Context())).
+			With("method", r.Method).
 			With("path", r.URL.Path).
 			With("status", rw.statusCode).
 			With("duration", duration.String()).
@@ -109,14 +140,29 @@ func (m *Middleware) Recovery(next http.Handler) http.Handler {
 	})
 }
 
-// RateLimit applies rate limiting based on client IP address
+// RateLimit applies rate limiting based on the API key when per-key limiting is
+// enabled and a valid key is sent, and on client IP address otherwise
 func (m *Middleware) RateLimit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientIP := getClientIP(r)
 
-		if !m.rateLimiter.Allow(clientIP) {
+		// Only valid keys get their own bucket, so made-up keys can't dodge the IP limit
+		apiKey := r.Header.Get("X-API-Key")
+		if m.rateLimiter.requestsPerKey > 0 && apiKey != "" && m.isValidAPIKey(apiKey) {
+			if allowed, wait := m.rateLimiter.AllowKey(apiKey); !allowed {
+				m.log.Warnf("Rate limit exceeded for API key from client: %s", clientIP)
+				w.Header().Set("Retry-After", retryAfter(wait))
+				http.Error(w, "Rate limit exceeded. Please try again later.", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if allowed, wait := m.rateLimiter.Allow(clientIP); !allowed {
 			m.log.Warnf("Rate limit exceeded for client: %s", clientIP)
-			w.Header().Set("Retry-After", "60")
+			w.Header().Set("Retry-After", retryAfter(wait))
 			http.Error(w, "Rate limit exceeded. Please try again later.", http.StatusTooManyRequests)
 			return
 		}
@@ -125,18 +171,77 @@ func (m *Middleware) RateLimit(next http.Handler) http.Handler {
 	})
 }
 
-// Allow checks if a request is allowed based on rate limiting
-func (rl *RateLimiter) Allow(clientIP string) bool {
+// Allow checks if a request from a client IP is allowed based on rate limiting.
+// If not, it also returns how long until the client's next token is available.
+func (rl *RateLimiter) Allow(clientIP string) (bool, time.Duration) {
+	return rl.allow("ip:"+clientIP, rl.requestsPerMinute)
+}
+
+// AllowKey checks if a request with an API key is allowed based on the per-key limit.
+// If not, it also returns how long until the key's next token is available.
+func (rl *RateLimiter) AllowKey(apiKey string) (bool, time.Duration) {
+	return rl.allow("key:"+apiKey, rl.requestsPerKey)
+}
+
+// staleBucketWindows is how many windows a bucket may go unrefilled before it is evicted
+const staleBucketWindows = 3
+
+// sweep periodically evicts buckets of clients that haven't made a request for a
+// few windows, so every distinct client doesn't leave a permanent entry
+func (rl *RateLimiter) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rl.stop:
+			return
+		case <-ticker.C:
+			rl.evictStale(time.Now().Add(-staleBucketWindows * rl.windowSize))
+		}
+	}
+}
+
+// evictStale deletes buckets last refilled before the cutoff
+func (rl *RateLimiter) evictStale(cutoff time.Time) {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
-	bucket, exists := rl.clients[clientIP]
+	for client, bucket := range rl.clients {
+		bucket.mutex.Lock()
+		stale := bucket.lastRefill.Before(cutoff)
+		bucket.mutex.Unlock()
+
+		if stale {
+			delete(rl.clients, client)
+		}
+	}
+}
+
+// retryAfter formats a wait in whole seconds, rounded up and at least 1, for the Retry-After header
+func retryAfter(wait time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(wait.Seconds()))))
+}
+
+// TimeUntilToken returns how long until the bucket is next refilled, given the refill window.
+// The caller must hold the bucket's mutex.
+func (b *ClientBucket) TimeUntilToken(window time.Duration) time.Duration {
+	return max(0, time.Until(b.lastRefill.Add(window)))
+}
+
+// allow takes a token from the client's bucket, refilling it to limit once per window.
+// When no token is left it returns false and the time until the bucket refills.
+func (rl *RateLimiter) allow(client string, limit int) (bool, time.Duration) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	bucket, exists := rl.clients[client]
 	if !exists {
 		bucket = &ClientBucket{
-			tokens:     rl.requestsPerMinute,
+			tokens:     limit,
 			lastRefill: time.Now(),
 		}
-		rl.clients[clientIP] = bucket
+		rl.clients[client] = bucket
 	}
 
 	bucket.mutex.Lock()
@@ -147,17 +252,17 @@ func (rl *RateLimiter) Allow(clientIP string) bool {
 	elapsed := now.Sub(bucket.lastRefill)
 
 	if elapsed >= rl.windowSize {
-		bucket.tokens = rl.requestsPerMinute
+		bucket.tokens = limit
 		bucket.lastRefill = now
 	}
 
 	// Check if tokens are available
 	if bucket.tokens > 0 {
 		bucket.tokens--
-		return true
+		return true, 0
 	}
 
-	return false
+	return false, bucket.TimeUntilToken(rl.windowSize)
 }
 
 // getClientIP extracts the client IP address from the request
@@ -193,15 +298,26 @@ func (m *Middleware) ContentType(next http.Handler) http.Handler {
 // APIKeyAuth validates API key authentication
 func (m *Middleware) APIKeyAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip auth for health endpoint and webhook endpoints
-		if r.URL.Path == "/health" || r.URL.Path == "/webhook/gitea" || r.URL.Path == "/webhook/github" {
+		// Skip auth for health endpoints, webhook endpoints (they verify their own secrets)
+		// and confirmation links (opened by recipients, authenticated by their signed token)
+		if r.URL.Path == "/health" || r.URL.Path == "/ready" || r.URL.Path == "/readyz" ||
+			strings.HasPrefix(r.URL.Path, "/webhook/") || strings.HasPrefix(r.URL.Path, "/confirm/") {
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		// Get API key from header or query parameter
 		apiKey := r.Header.Get("X-API-Key")
-		if apiKey == "" {
+		if apiKey == "" && r.URL.Query().Get("api_key") != "" {
+			// Query strings end up in access logs and proxies, leaking the key
+			if m.apiKeyHeaderOnly {
+				m.log.Warnf("Rejected API key passed via query parameter from %s", getClientIP(r))
+				w.Header().Set("Content-Type", "application/json")
+				http.Error(w, `{"error":"API key must be sent in the X-API-Key header","code":"UNAUTHORIZED"}`, http.StatusUnauthorized)
+				return
+			}
+
+			m.log.Warnf("API key passed via query parameter from %s; use the X-API-Key header to avoid leaking it in logs", getClientIP(r))
 			apiKey = r.URL.Query().Get("api_key")
 		}
 
@@ -226,6 +342,9 @@ func (m *Middleware) APIKeyAuth(next http.Handler) http.Handler {
 
 // isValidAPIKey validates API key using constant-time comparison
 func (m *Middleware) isValidAPIKey(providedKey string) bool {
+	m.keysMutex.RLock()
+	defer m.keysMutex.RUnlock()
+
 	for validKey := range m.apiKeys {
 		if subtle.ConstantTimeCompare([]byte(providedKey), []byte(validKey)) == 1 {
 			return true
@@ -267,3 +386,10 @@ func (rw *responseWriter) WriteHeader(statusCode int) {
 	rw.statusCode = statusCode
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Flush implements http.Flusher so streaming handlers can flush through the wrapper
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Alertmanager alert statuses
+const (
+	AlertmanagerStatusFiring   = "firing"
+	AlertmanagerStatusResolved = "resolved"
+)
+
+// AlertmanagerPayload represents a Prometheus Alertmanager webhook notification for one alert group
+type AlertmanagerPayload struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	TruncatedAlerts   int                 `json:"truncatedAlerts"` // Alerts left out because of max_alerts
+	Status            string              `json:"status"`          // "firing" if any alert is firing, otherwise "resolved"
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerAlert holds a single alert of an Alertmanager notification
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// ShouldNotify reports whether the notification carries any alerts
+func (p AlertmanagerPayload) ShouldNotify() bool {
+	return len(p.Alerts) > 0
+}
+
+// GetRepositoryName returns the alert name shared by the group, falling back to the receiver
+func (p AlertmanagerPayload) GetRepositoryName() string {
+	if name := p.CommonLabels["alertname"]; name != "" {
+		return name
+	}
+	return p.Receiver
+}
+
+// GetPusherName returns an empty string (alerts have no pusher)
+func (p AlertmanagerPayload) GetPusherName() string {
+	return ""
+}
+
+// GetBranch returns an empty string (alerts have no branch)
+func (p AlertmanagerPayload) GetBranch() string {
+	return ""
+}
+
+// GetCommitCount returns zero (alerts have no commits)
+func (p AlertmanagerPayload) GetCommitCount() int {
+	return 0
+}
+
+// GetCommits returns no commits
+func (p AlertmanagerPayload) GetCommits() []CommitInfo {
+	return []CommitInfo{}
+}
+
+// GetFileChangeSummary returns an empty file change summary
+func (p AlertmanagerPayload) GetFileChangeSummary() FileChangeSummary {
+	return FileChangeSummary{
+		AddedFiles:    []string{},
+		ModifiedFiles: []string{},
+		RemovedFiles:  []string{},
+	}
+}
+
+// GetCompareURL returns the Alertmanager URL
+func (p AlertmanagerPayload) GetCompareURL() string {
+	return p.ExternalURL
+}
+
+// GetTimestamp returns the zero time: alert start times say nothing about when the
+// notification was delivered, and long-running alerts would trip the delivery age check
+func (p AlertmanagerPayload) GetTimestamp() time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,178 @@
+package models
+
+import "time"
+
+// BitbucketWebhookPayload represents the Bitbucket Cloud repo:push webhook payload
+type BitbucketWebhookPayload struct {
+	Actor      BitbucketUser       `json:"actor"`
+	Repository BitbucketRepository `json:"repository"`
+	Push       BitbucketPush       `json:"push"`
+}
+
+// BitbucketPush holds the ref changes of a push
+type BitbucketPush struct {
+	Changes []BitbucketChange `json:"changes"`
+}
+
+// BitbucketChange represents a single ref change in a push
+type BitbucketChange struct {
+	New       *BitbucketRef     `json:"new"` // nil when the ref was deleted
+	Old       *BitbucketRef     `json:"old"` // nil when the ref was created
+	Links     BitbucketLinks    `json:"links"`
+	Created   bool              `json:"created"`
+	Forced    bool              `json:"forced"`
+	Closed    bool              `json:"closed"`
+	Truncated bool              `json:"truncated"`
+	Commits   []BitbucketCommit `json:"commits"` // Newest first
+}
+
+// BitbucketRef represents a branch or tag in a ref change
+type BitbucketRef struct {
+	Type   string          `json:"type"`
+	Name   string          `json:"name"`
+	Target BitbucketCommit `json:"target"`
+}
+
+// BitbucketCommit represents a commit in the Bitbucket webhook
+type BitbucketCommit struct {
+	Hash    string            `json:"hash"`
+	Message string            `json:"message"`
+	Date    string            `json:"date"`
+	Author  BitbucketAuthor   `json:"author"`
+	Links   BitbucketLinks    `json:"links"`
+	Parents []BitbucketParent `json:"parents"`
+}
+
+// BitbucketParent represents a parent of a commit
+type BitbucketParent struct {
+	Hash string `json:"hash"`
+}
+
+// BitbucketAuthor represents a commit author
+type BitbucketAuthor struct {
+	Raw  string        `json:"raw"`
+	User BitbucketUser `json:"user"`
+}
+
+// BitbucketRepository represents a repository in the Bitbucket webhook
+type BitbucketRepository struct {
+	Name     string         `json:"name"`
+	FullName string         `json:"full_name"`
+	Links    BitbucketLinks `json:"links"`
+}
+
+// BitbucketUser represents a user in the Bitbucket webhook
+type BitbucketUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	UUID        string `json:"uuid"`
+}
+
+// BitbucketLinks holds the links of a Bitbucket object
+type BitbucketLinks struct {
+	HTML BitbucketLink `json:"html"`
+}
+
+// BitbucketLink represents a single link
+type BitbucketLink struct {
+	Href string `json:"href"`
+}
+
+// GetRepositoryName returns the full repository name
+func (p BitbucketWebhookPayload) GetRepositoryName() string {
+	return p.Repository.FullName
+}
+
+// GetPusherName returns the pusher's name
+func (p BitbucketWebhookPayload) GetPusherName() string {
+	if p.Actor.DisplayName != "" {
+		return p.Actor.DisplayName
+	}
+	return p.Actor.Nickname
+}
+
+// IsForced reports whether any ref in the push was force pushed
+func (p BitbucketWebhookPayload) IsForced() bool {
+	for _, change := range p.Push.Changes {
+		if change.Forced {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBranch returns the name of the first changed ref
+func (p BitbucketWebhookPayload) GetBranch() string {
+	if len(p.Push.Changes) == 0 {
+		return ""
+	}
+	change := p.Push.Changes[0]
+	if change.New != nil {
+		return change.New.Name
+	}
+	// Deleted branch
+	if change.Old != nil {
+		return change.Old.Name
+	}
+	return ""
+}
+
+// GetCommitCount returns the number of commits across all changes
+func (p BitbucketWebhookPayload) GetCommitCount() int {
+	count := 0
+	for _, change := range p.Push.Changes {
+		count += len(change.Commits)
+	}
+	return count
+}
+
+// GetCommits returns commits across all changes in a generic format, oldest first
+func (p BitbucketWebhookPayload) GetCommits() []CommitInfo {
+	commits := make([]CommitInfo, 0, p.GetCommitCount())
+	for _, change := range p.Push.Changes {
+		// Bitbucket lists commits newest first
+		for i := len(change.Commits) - 1; i >= 0; i-- {
+			c := change.Commits[i]
+			commits = append(commits, CommitInfo{
+				ID:      c.Hash,
+				Message: c.Message,
+				URL:     c.Links.HTML.Href,
+				Parents: len(c.Parents),
+				// Bitbucket push payloads don't include file change details
+				Added:    []string{},
+				Modified: []string{},
+				Removed:  []string{},
+			})
+		}
+	}
+	return commits
+}
+
+// GetFileChangeSummary returns an empty file change summary (Bitbucket doesn't provide this)
+func (p BitbucketWebhookPayload) GetFileChangeSummary() FileChangeSummary {
+	return FileChangeSummary{
+		AddedFiles:    []string{},
+		ModifiedFiles: []string{},
+		RemovedFiles:  []string{},
+	}
+}
+
+// GetCompareURL returns the compare URL of the first change
+func (p BitbucketWebhookPayload) GetCompareURL() string {
+	if len(p.Push.Changes) == 0 {
+		return ""
+	}
+	return p.Push.Changes[0].Links.HTML.Href
+}
+
+// GetTimestamp returns the date of the newest commit in the first change, or the zero time if unavailable
+func (p BitbucketWebhookPayload) GetTimestamp() time.Time {
+	if len(p.Push.Changes) == 0 || len(p.Push.Changes[0].Commits) == 0 {
+		return time.Time{}
+	}
+	timestamp, err := time.Parse(time.RFC3339, p.Push.Changes[0].Commits[0].Date)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// CustomWebhookPayload represents an arbitrary JSON payload rendered through CUSTOM_WEBHOOK_TEMPLATE
+type CustomWebhookPayload struct {
+	Fields    map[string]interface{} // Parsed JSON body the template is executed against
+	Recipient string                 // Recipient named in the payload, or the configured default
+	Message   string                 // Rendered notification
+}
+
+// GetRecipient returns the recipient of the notification
+func (p CustomWebhookPayload) GetRecipient() string {
+	return p.Recipient
+}
+
+// GetRepositoryName returns an empty string (custom payloads have no repository)
+func (p CustomWebhookPayload) GetRepositoryName() string {
+	return ""
+}
+
+// GetPusherName returns an empty string (custom payloads have no pusher)
+func (p CustomWebhookPayload) GetPusherName() string {
+	return ""
+}
+
+// GetBranch returns an empty string (custom payloads have no branch)
+func (p CustomWebhookPayload) GetBranch() string {
+	return ""
+}
+
+// GetCommitCount returns zero (custom payloads have no commits)
+func (p CustomWebhookPayload) GetCommitCount() int {
+	return 0
+}
+
+// GetCommits returns no commits
+func (p CustomWebhookPayload) GetCommits() []CommitInfo {
+	return []CommitInfo{}
+}
+
+// GetFileChangeSummary returns an empty file change summary
+func (p CustomWebhookPayload) GetFileChangeSummary() FileChangeSummary {
+	return FileChangeSummary{
+		AddedFiles:    []string{},
+		ModifiedFiles: []string{},
+		RemovedFiles:  []string{},
+	}
+}
+
+// GetCompareURL returns an empty string (custom payloads have no compare URL)
+func (p CustomWebhookPayload) GetCompareURL() string {
+	return ""
+}
+
+// GetTimestamp returns the zero time; custom payloads have no known timestamp field
+func (p CustomWebhookPayload) GetTimestamp() time.Time {
+	return time.Time{}
+}
@@ -1,10 +1,13 @@
 package models
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // GiteaWebhookPayload represents the Gitea webhook payload
 type GiteaWebhookPayload struct {
-	Secret     string          `json:"secret"`
+	Secret     string          `json:"secret"` // Sent by older Gitea versions; a credential, never log it
 	Ref        string          `json:"ref"`
 	Before     string          `json:"before"`
 	After      string          `json:"after"`
@@ -13,6 +16,7 @@ type GiteaWebhookPayload struct {
 	Repository GiteaRepository `json:"repository"`
 	Pusher     GiteaUser       `json:"pusher"`
 	Sender     GiteaUser       `json:"sender"`
+	Forced     bool            `json:"forced"` // Not sent by every Gitea version
 }
 
 // GiteaCommit represents a commit in the Gitea webhook
@@ -23,6 +27,9 @@ type GiteaCommit struct {
 	Author    GiteaUser `json:"author"`
 	Committer GiteaUser `json:"committer"`
 	Timestamp string    `json:"timestamp"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	Modified  []string  `json:"modified"`
 }
 
 // GiteaRepository represents a repository in the Gitea webhook
@@ -58,13 +65,32 @@ func (p GiteaWebhookPayload) GetRepositoryName() string {
 
 // GetPusherName returns the pusher's name
 func (p GiteaWebhookPayload) GetPusherName() string {
-	// Prefer committer name from first commit, fallback to pusher
-	if len(p.Commits) > 0 && p.Commits[0].Committer.Name != "" {
-		return p.Commits[0].Committer.Name
+	return p.GetPusherNameFrom(PusherSourceCommitter)
+}
+
+// GetPusherNameFrom returns the name of the identity selected by source
+// (a PusherSource value), falling back to the pusher
+func (p GiteaWebhookPayload) GetPusherNameFrom(source string) string {
+	if len(p.Commits) > 0 {
+		switch source {
+		case PusherSourceCommitter:
+			if p.Commits[0].Committer.Name != "" {
+				return p.Commits[0].Committer.Name
+			}
+		case PusherSourceAuthor:
+			if p.Commits[0].Author.Name != "" {
+				return p.Commits[0].Author.Name
+			}
+		}
 	}
 	return p.Pusher.Name
 }
 
+// IsForced reports whether the push was a force push
+func (p GiteaWebhookPayload) IsForced() bool {
+	return p.Forced
+}
+
 // GetBranch returns the branch name without refs/heads/ prefix
 func (p GiteaWebhookPayload) GetBranch() string {
 	return strings.TrimPrefix(p.Ref, "refs/heads/")
@@ -80,31 +106,35 @@ func (p GiteaWebhookPayload) GetCommits() []CommitInfo {
 	commits := make([]CommitInfo, len(p.Commits))
 	for i, c := range p.Commits {
 		commits[i] = CommitInfo{
-			ID:      c.ID,
-			Message: c.Message,
-			URL:     c.URL,
-			// Gitea webhook doesn't provide file change details in the payload
-			Added:    []string{},
-			Modified: []string{},
-			Removed:  []string{},
+			ID:       c.ID,
+			Message:  c.Message,
+			URL:      c.URL,
+			Added:    c.Added,
+			Modified: c.Modified,
+			Removed:  c.Removed,
 		}
 	}
 	return commits
 }
 
-// GetFileChangeSummary returns an empty file change summary (Gitea doesn't provide this)
+// GetFileChangeSummary returns aggregated file change statistics for all commits
 func (p GiteaWebhookPayload) GetFileChangeSummary() FileChangeSummary {
-	return FileChangeSummary{
-		TotalAdded:    0,
-		TotalModified: 0,
-		TotalRemoved:  0,
-		AddedFiles:    []string{},
-		ModifiedFiles: []string{},
-		RemovedFiles:  []string{},
-	}
+	return summarizeFileChanges(p.GetCommits())
 }
 
 // GetCompareURL returns the compare URL
 func (p GiteaWebhookPayload) GetCompareURL() string {
 	return p.CompareURL
 }
+
+// GetTimestamp returns the timestamp of the last commit, or the zero time if unavailable
+func (p GiteaWebhookPayload) GetTimestamp() time.Time {
+	if len(p.Commits) == 0 {
+		return time.Time{}
+	}
+	timestamp, err := time.Parse(time.RFC3339, p.Commits[len(p.Commits)-1].Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
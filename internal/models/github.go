@@ -1,6 +1,9 @@
 package models
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // GitHubWebhookPayload represents the GitHub webhook payload
 type GitHubWebhookPayload struct {
@@ -112,13 +115,32 @@ func (p GitHubWebhookPayload) GetRepositoryName() string {
 
 // GetPusherName returns the pusher's name
 func (p GitHubWebhookPayload) GetPusherName() string {
-	// Prefer committer name from first commit, fallback to pusher
-	if len(p.Commits) > 0 && p.Commits[0].Committer.Name != "" {
-		return p.Commits[0].Committer.Name
+	return p.GetPusherNameFrom(PusherSourceCommitter)
+}
+
+// GetPusherNameFrom returns the name of the identity selected by source
+// (a PusherSource value), falling back to the pusher
+func (p GitHubWebhookPayload) GetPusherNameFrom(source string) string {
+	if len(p.Commits) > 0 {
+		switch source {
+		case PusherSourceCommitter:
+			if p.Commits[0].Committer.Name != "" {
+				return p.Commits[0].Committer.Name
+			}
+		case PusherSourceAuthor:
+			if p.Commits[0].Author.Name != "" {
+				return p.Commits[0].Author.Name
+			}
+		}
 	}
 	return p.Pusher.Name
 }
 
+// IsForced reports whether the push was a force push
+func (p GitHubWebhookPayload) IsForced() bool {
+	return p.Forced
+}
+
 // GetBranch returns the branch name without refs/heads/ prefix
 func (p GitHubWebhookPayload) GetBranch() string {
 	return strings.TrimPrefix(p.Ref, "refs/heads/")
@@ -147,46 +169,164 @@ func (p GitHubWebhookPayload) GetCommits() []CommitInfo {
 
 // GetFileChangeSummary returns aggregated file change statistics for all commits
 func (p GitHubWebhookPayload) GetFileChangeSummary() FileChangeSummary {
-	summary := FileChangeSummary{
-		AddedFiles:    make([]string, 0),
-		ModifiedFiles: make([]string, 0),
-		RemovedFiles:  make([]string, 0),
+	return summarizeFileChanges(p.GetCommits())
+}
+
+// GetCompareURL returns the compare URL
+func (p GitHubWebhookPayload) GetCompareURL() string {
+	return p.Compare
+}
+
+// GetTimestamp returns the head commit timestamp, or the zero time if unavailable
+func (p GitHubWebhookPayload) GetTimestamp() time.Time {
+	timestamp, err := time.Parse(time.RFC3339, p.HeadCommit.Timestamp)
+	if err != nil {
+		return time.Time{}
 	}
+	return timestamp
+}
 
-	// Track unique files to avoid duplicates across commits
-	addedSet := make(map[string]bool)
-	modifiedSet := make(map[string]bool)
-	removedSet := make(map[string]bool)
+// GitHub event types, from the X-GitHub-Event header
+const (
+	GitHubEventPush        = "push"
+	GitHubEventPullRequest = "pull_request"
+	GitHubEventIssues      = "issues"
+)
 
-	for _, commit := range p.Commits {
-		for _, file := range commit.Added {
-			if !addedSet[file] {
-				addedSet[file] = true
-				summary.AddedFiles = append(summary.AddedFiles, file)
-			}
-		}
-		for _, file := range commit.Modified {
-			if !modifiedSet[file] {
-				modifiedSet[file] = true
-				summary.ModifiedFiles = append(summary.ModifiedFiles, file)
-			}
-		}
-		for _, file := range commit.Removed {
-			if !removedSet[file] {
-				removedSet[file] = true
-				summary.RemovedFiles = append(summary.RemovedFiles, file)
-			}
-		}
+// GitHubEvent holds the fields common to every GitHub event payload.
+// Payloads without commits embed it for the generic webhook accessors.
+type GitHubEvent struct {
+	Action     string           `json:"action"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+// GetRepositoryName returns the full repository name
+func (e GitHubEvent) GetRepositoryName() string {
+	return e.Repository.FullName
+}
+
+// GetPusherName returns the login of the user who triggered the event
+func (e GitHubEvent) GetPusherName() string {
+	return e.Sender.Login
+}
+
+// GetBranch returns an empty string (the event isn't tied to a pushed branch)
+func (e GitHubEvent) GetBranch() string {
+	return ""
+}
+
+// GetCommitCount returns zero (the event carries no commits)
+func (e GitHubEvent) GetCommitCount() int {
+	return 0
+}
+
+// GetCommits returns no commits
+func (e GitHubEvent) GetCommits() []CommitInfo {
+	return []CommitInfo{}
+}
+
+// GetFileChangeSummary returns an empty file change summary
+func (e GitHubEvent) GetFileChangeSummary() FileChangeSummary {
+	return FileChangeSummary{
+		AddedFiles:    []string{},
+		ModifiedFiles: []string{},
+		RemovedFiles:  []string{},
 	}
+}
 
-	summary.TotalAdded = len(summary.AddedFiles)
-	summary.TotalModified = len(summary.ModifiedFiles)
-	summary.TotalRemoved = len(summary.RemovedFiles)
+// GetCompareURL returns an empty string
+func (e GitHubEvent) GetCompareURL() string {
+	return ""
+}
 
-	return summary
+// GetTimestamp returns the zero time
+func (e GitHubEvent) GetTimestamp() time.Time {
+	return time.Time{}
 }
 
-// GetCompareURL returns the compare URL
-func (p GitHubWebhookPayload) GetCompareURL() string {
-	return p.Compare
+// GitHubIgnoredEvent is an event type that isn't formatted; it is acknowledged and skipped
+type GitHubIgnoredEvent struct {
+	GitHubEvent
+	Event string `json:"-"` // Value of the X-GitHub-Event header
+}
+
+// ShouldNotify always reports false
+func (p GitHubIgnoredEvent) ShouldNotify() bool {
+	return false
+}
+
+// GitHubPullRequestPayload represents a GitHub pull_request event payload
+type GitHubPullRequestPayload struct {
+	GitHubEvent
+	Number      int               `json:"number"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+}
+
+// GitHubPullRequest represents a pull request in a GitHub event
+type GitHubPullRequest struct {
+	Number    int           `json:"number"`
+	Title     string        `json:"title"`
+	HTMLURL   string        `json:"html_url"`
+	User      GitHubUser    `json:"user"`
+	Merged    bool          `json:"merged"`
+	MergedBy  *GitHubUser   `json:"merged_by"`
+	Head      GitHubPullRef `json:"head"`
+	Base      GitHubPullRef `json:"base"`
+	UpdatedAt string        `json:"updated_at"`
+}
+
+// GitHubPullRef represents the head or base branch of a pull request
+type GitHubPullRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// ShouldNotify reports whether the pull request was opened or closed (including merged)
+func (p GitHubPullRequestPayload) ShouldNotify() bool {
+	return p.Action == "opened" || p.Action == "closed"
+}
+
+// GetBranch returns the pull request's head branch
+func (p GitHubPullRequestPayload) GetBranch() string {
+	return p.PullRequest.Head.Ref
+}
+
+// GetTimestamp returns when the pull request was last updated, or the zero time if unavailable
+func (p GitHubPullRequestPayload) GetTimestamp() time.Time {
+	timestamp, err := time.Parse(time.RFC3339, p.PullRequest.UpdatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
+
+// GitHubIssuesPayload represents a GitHub issues event payload
+type GitHubIssuesPayload struct {
+	GitHubEvent
+	Issue GitHubIssue `json:"issue"`
+}
+
+// GitHubIssue represents an issue in a GitHub event
+type GitHubIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	HTMLURL     string     `json:"html_url"`
+	User        GitHubUser `json:"user"`
+	StateReason string     `json:"state_reason"` // completed or not_planned once closed
+	UpdatedAt   string     `json:"updated_at"`
+}
+
+// ShouldNotify reports whether the issue was opened or closed
+func (p GitHubIssuesPayload) ShouldNotify() bool {
+	return p.Action == "opened" || p.Action == "closed"
+}
+
+// GetTimestamp returns when the issue was last updated, or the zero time if unavailable
+func (p GitHubIssuesPayload) GetTimestamp() time.Time {
+	timestamp, err := time.Parse(time.RFC3339, p.Issue.UpdatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
 }
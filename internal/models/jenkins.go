@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// Jenkins build phases and statuses reported by the Notification plugin
+const (
+	JenkinsPhaseCompleted = "COMPLETED"
+
+	JenkinsStatusSuccess  = "SUCCESS"
+	JenkinsStatusFailure  = "FAILURE"
+	JenkinsStatusUnstable = "UNSTABLE"
+	JenkinsStatusAborted  = "ABORTED"
+)
+
+// JenkinsWebhookPayload represents the Jenkins Notification plugin JSON payload
+type JenkinsWebhookPayload struct {
+	Name  string       `json:"name"` // Job name
+	URL   string       `json:"url"`
+	Build JenkinsBuild `json:"build"`
+}
+
+// JenkinsBuild holds the build details of a Jenkins notification
+type JenkinsBuild struct {
+	FullURL   string     `json:"full_url"`
+	Number    int        `json:"number"`
+	Phase     string     `json:"phase"`  // QUEUED, STARTED, COMPLETED or FINALIZED
+	Status    string     `json:"status"` // SUCCESS, FAILURE, UNSTABLE, ABORTED, ...; only set once completed
+	URL       string     `json:"url"`
+	SCM       JenkinsSCM `json:"scm"`
+	Timestamp int64      `json:"timestamp"` // Build start time in milliseconds
+}
+
+// JenkinsSCM holds the source control details of a build
+type JenkinsSCM struct {
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+	Commit string `json:"commit"`
+}
+
+// ShouldNotify reports whether the build has completed; other phases are skipped to avoid spam
+func (p JenkinsWebhookPayload) ShouldNotify() bool {
+	return p.Build.Phase == JenkinsPhaseCompleted
+}
+
+// GetRepositoryName returns the job name
+func (p JenkinsWebhookPayload) GetRepositoryName() string {
+	return p.Name
+}
+
+// GetPusherName returns an empty string (Jenkins doesn't report who triggered the build)
+func (p JenkinsWebhookPayload) GetPusherName() string {
+	return ""
+}
+
+// GetBranch returns the built branch
+func (p JenkinsWebhookPayload) GetBranch() string {
+	return p.Build.SCM.Branch
+}
+
+// GetCommitCount returns the number of commits (one when the built commit is known)
+func (p JenkinsWebhookPayload) GetCommitCount() int {
+	return len(p.GetCommits())
+}
+
+// GetCommits returns the built commit, if known
+func (p JenkinsWebhookPayload) GetCommits() []CommitInfo {
+	if p.Build.SCM.Commit == "" {
+		return []CommitInfo{}
+	}
+	return []CommitInfo{{
+		ID:       p.Build.SCM.Commit,
+		Added:    []string{},
+		Modified: []string{},
+		Removed:  []string{},
+	}}
+}
+
+// GetFileChangeSummary returns an empty file change summary (Jenkins doesn't provide this)
+func (p JenkinsWebhookPayload) GetFileChangeSummary() FileChangeSummary {
+	return FileChangeSummary{
+		AddedFiles:    []string{},
+		ModifiedFiles: []string{},
+		RemovedFiles:  []string{},
+	}
+}
+
+// GetCompareURL returns the build URL
+func (p JenkinsWebhookPayload) GetCompareURL() string {
+	return p.Build.FullURL
+}
+
+// GetTimestamp returns the zero time: the build start time says nothing about when
+// the notification was delivered, and long builds would trip the delivery age check
+func (p JenkinsWebhookPayload) GetTimestamp() time.Time {
+	return time.Time{}
+}
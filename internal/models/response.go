@@ -5,13 +5,25 @@ type HealthResponse struct {
 	Status    string `json:"status"`
 	Connected bool   `json:"connected"`
 	Timestamp int64  `json:"timestamp"`
+
+	ReconnectExhausted bool `json:"reconnect_exhausted,omitempty"` // Reconnection gave up; the session needs re-authentication
+}
+
+// ReadinessResponse represents the readiness check response
+type ReadinessResponse struct {
+	Status     string `json:"status"`
+	Connected  bool   `json:"connected"`
+	ProbeJID   string `json:"probe_jid,omitempty"`
+	ProbeError string `json:"probe_error,omitempty"`
+	Timestamp  int64  `json:"timestamp"`
 }
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code,omitempty"`
-	Details string `json:"details,omitempty"`
+	Error     string `json:"error"`
+	Code      string `json:"code,omitempty"`
+	Details   string `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // StatusResponse represents a generic status response
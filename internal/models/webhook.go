@@ -1,5 +1,23 @@
 package models
 
+import (
+	"strings"
+	"text/template"
+)
+
+// Identities that can be shown as the pusher of a push notification
+const (
+	PusherSourceCommitter = "committer" // First commit's committer, falling back to the pusher
+	PusherSourceAuthor    = "author"    // First commit's author, falling back to the pusher
+	PusherSourcePusher    = "pusher"    // Account that pushed
+)
+
+// How push notifications show changed files
+const (
+	FileChangesList   = "list"   // Totals, each followed by up to 20 file names
+	FileChangesCounts = "counts" // Totals only
+)
+
 // CommitInfo holds common commit information across different webhook providers
 type CommitInfo struct {
 	ID       string
@@ -8,6 +26,52 @@ type CommitInfo struct {
 	Added    []string // Files added in this commit
 	Modified []string // Files modified in this commit
 	Removed  []string // Files removed in this commit
+	Parents  int      // Number of parent commits (0 if the provider doesn't report them)
+}
+
+// IsMerge reports whether the commit is a merge commit, judged by its parents
+// when the provider reports them and by its message otherwise
+func (c CommitInfo) IsMerge() bool {
+	if c.Parents > 0 {
+		return c.Parents > 1
+	}
+	return strings.HasPrefix(c.Message, "Merge ")
+}
+
+// MessageTemplateFuncs are the helper functions available to webhook message templates
+var MessageTemplateFuncs = template.FuncMap{
+	"shortHash": ShortHash,
+	"firstLine": FirstLine,
+	"truncate":  Truncate,
+}
+
+// ShortHash returns the abbreviated (7 character) form of a commit hash
+func ShortHash(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+// FirstLine returns the first line of a commit message
+func FirstLine(message string) string {
+	if idx := strings.Index(message, "\n"); idx != -1 {
+		return message[:idx]
+	}
+	return message
+}
+
+// Truncate shortens s to at most n characters, ending it with "..." when cut.
+// The length comes first so templates can pipe into it: {{.Message | truncate 60}}
+func Truncate(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
 }
 
 // FileChangeSummary holds aggregated file change statistics
@@ -19,3 +83,64 @@ type FileChangeSummary struct {
 	ModifiedFiles []string
 	RemovedFiles  []string
 }
+
+// summarizeFileChanges aggregates the file changes of commits, listing each file once per change type
+func summarizeFileChanges(commits []CommitInfo) FileChangeSummary {
+	summary := FileChangeSummary{
+		AddedFiles:    make([]string, 0),
+		ModifiedFiles: make([]string, 0),
+		RemovedFiles:  make([]string, 0),
+	}
+
+	// Track unique files to avoid duplicates across commits
+	addedSet := make(map[string]bool)
+	modifiedSet := make(map[string]bool)
+	removedSet := make(map[string]bool)
+
+	for _, commit := range commits {
+		for _, file := range commit.Added {
+			if !addedSet[file] {
+				addedSet[file] = true
+				summary.AddedFiles = append(summary.AddedFiles, file)
+			}
+		}
+		for _, file := range commit.Modified {
+			if !modifiedSet[file] {
+				modifiedSet[file] = true
+				summary.ModifiedFiles = append(summary.ModifiedFiles, file)
+			}
+		}
+		for _, file := range commit.Removed {
+			if !removedSet[file] {
+				removedSet[file] = true
+				summary.RemovedFiles = append(summary.RemovedFiles, file)
+			}
+		}
+	}
+
+	summary.TotalAdded = len(summary.AddedFiles)
+	summary.TotalModified = len(summary.ModifiedFiles)
+	summary.TotalRemoved = len(summary.RemovedFiles)
+
+	return summary
+}
+
+// WebhookResponse represents the response after a webhook notification is sent
+type WebhookResponse struct {
+	Status     string   `json:"status"`
+	Provider   string   `json:"provider"`
+	Recipient  string   `json:"recipient"`
+	Recipients []string `json:"recipients,omitempty"` // All recipients, when routed to more than one
+	Repository string   `json:"repository"`
+	MessageID  string   `json:"message_id,omitempty"`
+	Message    string   `json:"message,omitempty"` // Formatted message, only included for debug and dry-run requests
+}
+
+// WebhookDeliveryInfo represents a recorded webhook delivery, with secrets redacted
+type WebhookDeliveryInfo struct {
+	ID         string            `json:"id"`
+	Provider   string            `json:"provider"`
+	ReceivedAt int64             `json:"received_at"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
@@ -9,6 +9,20 @@ type ContactInfo struct {
 	FullName     string `json:"full_name,omitempty"`
 }
 
+// ContactListResponse represents a page of contacts (streamed by /contacts in this shape)
+type ContactListResponse struct {
+	Total    int           `json:"total"` // Number of contacts matching the search, before paging
+	Limit    int           `json:"limit"`
+	Offset   int           `json:"offset"`
+	Contacts []ContactInfo `json:"contacts"`
+}
+
+// NumberCheckResult represents the WhatsApp registration status of a phone number
+type NumberCheckResult struct {
+	Registered bool   `json:"registered"`
+	JID        string `json:"jid,omitempty"` // Only set for registered numbers
+}
+
 // GroupInfo represents a WhatsApp group
 type GroupInfo struct {
 	JID         string `json:"jid"`
@@ -20,16 +34,254 @@ type GroupInfo struct {
 	CreatedAt   int64  `json:"created_at,omitempty"`
 }
 
+// GroupListResponse represents a page of joined groups
+type GroupListResponse struct {
+	Total  int         `json:"total"` // Number of groups matching the filters, before paging
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+	Groups []GroupInfo `json:"groups"`
+}
+
+// GroupMember represents a participant of a WhatsApp group
+type GroupMember struct {
+	JID          string `json:"jid"`
+	PhoneNumber  string `json:"phone_number,omitempty"` // Phone number JID, when the member is addressed by LID
+	IsAdmin      bool   `json:"is_admin"`
+	IsSuperAdmin bool   `json:"is_super_admin"`
+	PushName     string `json:"push_name,omitempty"`
+}
+
+// GroupMembersResponse represents the members of a group
+type GroupMembersResponse struct {
+	JID     string        `json:"jid"`
+	Total   int           `json:"total"`
+	Members []GroupMember `json:"members"`
+}
+
+// Priority represents the delivery priority of an outgoing message
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"    // Served after other sends; rejected while the send queue is full
+	PriorityNormal Priority = "normal" // Default; subject to the per-recipient cooldown
+	PriorityUrgent Priority = "urgent" // Skips the per-recipient cooldown and is served first
+)
+
+// IsValid reports whether p is a known priority (empty means normal)
+func (p Priority) IsValid() bool {
+	switch p {
+	case "", PriorityLow, PriorityNormal, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns p, or PriorityNormal if p is empty
+func (p Priority) OrDefault() Priority {
+	if p == "" {
+		return PriorityNormal
+	}
+	return p
+}
+
+// LastRecipient is a special "to" value that resolves to the most recently messaged recipient
+const LastRecipient = "@last"
+
 // SendMessageRequest represents the request payload for sending messages
 type SendMessageRequest struct {
-	To      string `json:"to" validate:"required"`
-	Message string `json:"message" validate:"required,min=1"`
+	To       string   `json:"to" validate:"required"`
+	Message  string   `json:"message" validate:"required,min=1"`
+	Priority Priority `json:"priority,omitempty"`
+
+	// Forwarded marks the message as "Forwarded many times"; defaults to the server setting
+	Forwarded *bool `json:"forwarded,omitempty"`
+
+	// Reply to a prior message; both fields must be set together
+	QuotedMessageID string `json:"quoted_message_id,omitempty"`
+	QuotedJID       string `json:"quoted_jid,omitempty"` // Sender of the quoted message
+
+	// Raw sends the message verbatim, skipping sanitization; only honored for RAW_API_KEYS
+	Raw bool `json:"raw,omitempty"`
+
+	// DisappearingTimer sets the disappearing-message timer in seconds (0 for a regular message);
+	// defaults to the chat's timer
+	DisappearingTimer *uint32 `json:"disappearing_timer,omitempty"`
+
+	// SimulateTyping shows a "typing…" indicator for WHATSAPP_TYPING_DELAY before sending
+	SimulateTyping bool `json:"simulate_typing,omitempty"`
+
+	// OmitFooter sends the message without MESSAGE_FOOTER
+	OmitFooter bool `json:"omit_footer,omitempty"`
+
+	// RequestConfirmation appends a signed link the recipient opens to confirm receipt
+	RequestConfirmation bool `json:"request_confirmation,omitempty"`
+}
+
+// ScheduleMessageRequest represents the request payload for sending a message at a future time
+type ScheduleMessageRequest struct {
+	SendMessageRequest
+	SendAt string `json:"send_at"` // RFC3339 timestamp
+}
+
+// ScheduleMessageResponse represents a scheduled or cancelled message
+type ScheduleMessageResponse struct {
+	ID       string   `json:"id"`
+	Status   string   `json:"status"`
+	To       string   `json:"to"`
+	Priority Priority `json:"priority,omitempty"`
+	SendAt   int64    `json:"send_at"`
+}
+
+// SendGroupMessageRequest represents the request payload for sending a group message with @mentions
+type SendGroupMessageRequest struct {
+	To         string   `json:"to"`       // Group JID (…@g.us)
+	Message    string   `json:"message"`  // Must contain an @number token for each mention
+	Mentions   []string `json:"mentions"` // Individual JIDs to tag
+	Priority   Priority `json:"priority,omitempty"`
+	OmitFooter bool     `json:"omit_footer,omitempty"` // Send without MESSAGE_FOOTER
+}
+
+// SendBulkMessageRequest represents the request payload for sending one message to many recipients
+type SendBulkMessageRequest struct {
+	To         []string `json:"to"`
+	Message    string   `json:"message"`
+	Priority   Priority `json:"priority,omitempty"`
+	OmitFooter bool     `json:"omit_footer,omitempty"` // Send without MESSAGE_FOOTER
+}
+
+// BulkSendResult represents the outcome of a bulk send for one recipient
+type BulkSendResult struct {
+	To        string `json:"to"`
+	Status    string `json:"status"` // "sent", "failed" or "skipped"
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SendBulkMessageResponse represents the response after a bulk send, in request order
+type SendBulkMessageResponse struct {
+	Sent      int              `json:"sent"`
+	Failed    int              `json:"failed"` // Failed or skipped
+	Priority  Priority         `json:"priority,omitempty"`
+	Results   []BulkSendResult `json:"results"`
+	Timestamp int64            `json:"timestamp"`
+}
+
+// SendReactionRequest represents the request payload for reacting to a message
+type SendReactionRequest struct {
+	To        string `json:"to"`               // Chat containing the message
+	MessageID string `json:"message_id"`       // Message to react to
+	Sender    string `json:"sender,omitempty"` // Author of the message; omit for our own messages
+	Emoji     string `json:"emoji"`            // Empty removes the reaction
+}
+
+// SendMediaRequest represents the JSON request payload for sending media messages
+type SendMediaRequest struct {
+	To      string `json:"to"`
+	Caption string `json:"caption,omitempty"`
+	Data    string `json:"data"` // Base64-encoded file contents
+
+	// Document-only fields
+	FileName string `json:"file_name,omitempty"`
+	Mimetype string `json:"mimetype,omitempty"` // Detected from the content if omitted
 }
 
 // SendMessageResponse represents the response after sending a message
 type SendMessageResponse struct {
-	Status    string `json:"status"`
+	Status    string   `json:"status"`
+	To        string   `json:"to"`
+	MessageID string   `json:"message_id,omitempty"`
+	Priority  Priority `json:"priority,omitempty"`
+	Timestamp int64    `json:"timestamp"`
+
+	ConfirmationID string `json:"confirmation_id,omitempty"` // Set when a confirmation link was included
+}
+
+// Confirmation statuses
+const (
+	ConfirmationPending   = "pending"
+	ConfirmationConfirmed = "confirmed"
+)
+
+// ConfirmationInfo represents the state of a receipt confirmation link
+type ConfirmationInfo struct {
+	ID          string `json:"id"`
+	To          string `json:"to"`
+	MessageID   string `json:"message_id,omitempty"`
+	Status      string `json:"status"`
+	ExpiresAt   int64  `json:"expires_at"`
+	ConfirmedAt int64  `json:"confirmed_at,omitempty"`
+}
+
+// OutgoingMessageInfo represents the delivery state of a sent message
+type OutgoingMessageInfo struct {
+	ID        string `json:"id"`
 	To        string `json:"to"`
-	MessageID string `json:"message_id,omitempty"`
+	Status    string `json:"status"`
+	SentAt    int64  `json:"sent_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// ConnectionTransitionInfo represents a recorded change of WhatsApp connection state
+type ConnectionTransitionInfo struct {
+	State     string `json:"state"`
 	Timestamp int64  `json:"timestamp"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SentMessageLogEntry represents an entry in the outbound message audit log
+type SentMessageLogEntry struct {
+	ID          int64  `json:"id"`
+	MessageID   string `json:"message_id,omitempty"`
+	To          string `json:"to"`
+	BodyPreview string `json:"body_preview"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+// PairPhoneRequest represents the request payload for phone-number pairing
+type PairPhoneRequest struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
+// QRCodeResponse represents the QR code currently awaiting a scan
+type QRCodeResponse struct {
+	Code        string `json:"code"`
+	Image       string `json:"image"` // PNG as a data URL, usable directly as an <img> src
+	GeneratedAt int64  `json:"generated_at"`
+}
+
+// PairPhoneResponse represents the response containing the pairing code
+type PairPhoneResponse struct {
+	Code      string `json:"code"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ReloadConfigResponse represents the result of a configuration reload
+type ReloadConfigResponse struct {
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// IncomingMessageEvent represents an incoming message as POSTed to EVENT_FORWARD_URL
+type IncomingMessageEvent struct {
+	MessageID string `json:"message_id"`
+	Chat      string `json:"chat"`
+	Sender    string `json:"sender"`
+	PushName  string `json:"push_name,omitempty"`
+	IsGroup   bool   `json:"is_group"`
+	Text      string `json:"text,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+
+	// Set when WHATSAPP_SENDER_AVATAR_TTL is enabled and the sender has a visible picture
+	SenderAvatarURL string `json:"sender_avatar_url,omitempty"`
+}
+
+// DeadLetterEntry represents a forwarded event that couldn't be delivered, as written
+// to one line of EVENT_DEAD_LETTER_FILE
+type DeadLetterEntry struct {
+	Event    IncomingMessageEvent `json:"event"`
+	Error    string               `json:"error"`
+	Attempts int                  `json:"attempts"`
+	FailedAt int64                `json:"failed_at"`
 }
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/config"
@@ -17,31 +18,71 @@ type Server struct {
 	handler    *handlers.Handler
 	middleware *middleware.Middleware
 	log        *logger.Logger
+	serveErr   chan error // Receives the error if the server stops serving unexpectedly
 }
 
 // New creates a new HTTP server
 func New(cfg *config.Config, handler *handlers.Handler, log *logger.Logger) *Server {
-	mw := middleware.New(log)
+	mw := middleware.New(log, cfg.RateLimit)
 	mw.SetAPIKeys(cfg.Security.APIKeys)
+	mw.SetAPIKeyHeaderOnly(cfg.Security.APIKeyHeaderOnly)
+	handler.OnReload(func(cfg *config.Config) {
+		mw.SetAPIKeys(cfg.Security.APIKeys)
+	})
 
 	return &Server{
 		handler:    handler,
 		middleware: mw,
 		log:        log,
+		serveErr:   make(chan error, 1),
 	}
 }
 
-// Start starts the HTTP server
+// Start binds the listening address and serves HTTP in the background. Binding
+// errors (e.g. the port is in use) are returned; errors once serving are sent to Err.
 func (s *Server) Start(cfg *config.Config) error {
 	mux := http.NewServeMux()
 
 	// Register routes
 	mux.HandleFunc("/health", s.handler.HealthCheck)
+	mux.HandleFunc("/ready", s.handler.Readiness)
+	mux.HandleFunc("/readyz", s.handler.ProbedReadiness)
 	mux.HandleFunc("/contacts", s.handler.GetContacts)
+	mux.HandleFunc("/contacts/export", s.handler.ExportContacts)
+	mux.HandleFunc("/contacts/check", s.handler.CheckNumbers)
+	mux.HandleFunc("/contacts/{jid}", s.handler.GetContact)
 	mux.HandleFunc("/groups", s.handler.GetGroups)
+	mux.HandleFunc("/groups/{jid}/members", s.handler.GetGroupMembers)
 	mux.HandleFunc("/send", s.handler.SendMessage)
+	mux.HandleFunc("/send/image", s.handler.SendImage)
+	mux.HandleFunc("/send/document", s.handler.SendDocument)
+	mux.HandleFunc("/send/group", s.handler.SendGroupMessage)
+	mux.HandleFunc("/send/bulk", s.handler.SendBulkMessage)
+	mux.HandleFunc("/send/reaction", s.handler.SendReaction)
+	mux.HandleFunc("/send/schedule", s.handler.ScheduleMessage)
+	mux.HandleFunc("/send/schedule/{id}", s.handler.CancelScheduledMessage)
+	mux.HandleFunc("/messages/outgoing", s.handler.GetOutgoingMessages)
+	mux.HandleFunc("/messages/log", s.handler.GetMessageLog)
+	mux.HandleFunc("/messages/{id}/status", s.handler.GetMessageStatus)
+	mux.HandleFunc("/auth/pair", s.handler.PairPhone)
+	mux.HandleFunc("/auth/qr", s.handler.GetQRCode)
 	mux.HandleFunc("/webhook/gitea", s.handler.GiteaWebhook)
 	mux.HandleFunc("/webhook/github", s.handler.GitHubWebhook)
+	mux.HandleFunc("/webhook/bitbucket", s.handler.BitbucketWebhook)
+	mux.HandleFunc("/webhook/jenkins", s.handler.JenkinsWebhook)
+	mux.HandleFunc("/webhook/alertmanager", s.handler.AlertmanagerWebhook)
+	mux.HandleFunc("/webhook/custom", s.handler.CustomWebhook)
+	mux.HandleFunc("/admin/webhooks/recent", s.handler.GetRecentWebhooks)
+	mux.HandleFunc("/admin/webhooks/{id}/replay", s.handler.ReplayWebhook)
+	mux.HandleFunc("/admin/connection-history", s.handler.GetConnectionHistory)
+	mux.HandleFunc("/admin/reload-config", s.handler.ReloadConfig)
+	mux.HandleFunc("/confirm/{token}", s.handler.ConfirmReceipt)
+	mux.HandleFunc("/confirmations/{id}", s.handler.GetConfirmation)
+
+	// Catch-all for unregistered routes
+	if cfg.Server.JSONNotFound {
+		mux.HandleFunc("/", s.handler.NotFound)
+	}
 
 	// Apply middleware chain
 	handler := s.middleware.Recovery(mux)
@@ -50,6 +91,7 @@ func (s *Server) Start(cfg *config.Config) error {
 	handler = s.middleware.CORS(handler)
 	handler = s.middleware.RateLimit(handler)
 	handler = s.middleware.APIKeyAuth(handler) // Add API key authentication
+	handler = s.middleware.RequestID(handler)  // Outermost, so every response carries the ID
 
 	s.httpServer = &http.Server{
 		Addr:         cfg.Server.Address(),
@@ -58,23 +100,36 @@ func (s *Server) Start(cfg *config.Config) error {
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
-	s.log.Infof("HTTP server listening on %s", cfg.Server.Address())
+	// Bind before returning so a port conflict is reported to the caller
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.httpServer.Addr, err)
+	}
+
+	s.log.Infof("HTTP server listening on %s", listener.Addr())
 
-	// Start server in a goroutine
+	// Serve in a goroutine
 	go func() {
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.log.Fatal("HTTP server error", err)
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.serveErr <- err
 		}
 	}()
 
 	return nil
 }
 
+// Err returns a channel that receives the error if the server stops serving
+// other than through Shutdown
+func (s *Server) Err() <-chan error {
+	return s.serveErr
+}
+
 // Shutdown gracefully shuts down the HTTP server
 func (s *Server) Shutdown(ctx context.Context) error {
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
 	}
+	s.middleware.Close()
 
 	s.log.Info("HTTP server shutdown complete")
 	return nil
@@ -0,0 +1,121 @@
+package validation
+
+import "strings"
+
+// E.164 limits a full international number (country code included) to 15 digits
+const maxE164Digits = 15
+
+// countryCallingCodes lists the assigned ITU country calling codes. Codes are
+// prefix-free, so at most one of them can start a number.
+var countryCallingCodes = makeCodeSet(`
+	1 7
+	20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49 51 52 53 54 55 56 57 58
+	60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98
+	211 212 213 216 218 220 221 222 223 224 225 226 227 228 229
+	230 231 232 233 234 235 236 237 238 239 240 241 242 243 244 245 246 247 248 249
+	250 251 252 253 254 255 256 257 258 260 261 262 263 264 265 266 267 268 269
+	290 291 297 298 299 350 351 352 353 354 355 356 357 358 359
+	370 371 372 373 374 375 376 377 378 379 380 381 382 383 385 386 387 389
+	420 421 423 500 501 502 503 504 505 506 507 508 509
+	590 591 592 593 594 595 596 597 598 599
+	670 672 673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692
+	850 852 853 855 856 880 886
+	960 961 962 963 964 965 966 967 968 970 971 972 973 974 975 976 977
+	992 993 994 995 996 998
+`)
+
+// nationalNumberRule describes the national significant number (the digits after
+// the country code) for countries whose numbering plan is checked more strictly
+type nationalNumberRule struct {
+	minLen, maxLen int
+	trunkZero      bool // Numbers are dialled domestically with a leading 0 that is dropped internationally
+}
+
+var nationalNumberRules = map[string]nationalNumberRule{
+	"1":   {10, 10, false}, // US, Canada and the rest of the NANP
+	"7":   {10, 10, false}, // Russia, Kazakhstan
+	"20":  {9, 10, true},   // Egypt
+	"27":  {9, 9, true},    // South Africa
+	"31":  {9, 9, true},    // Netherlands
+	"33":  {9, 9, true},    // France
+	"34":  {9, 9, false},   // Spain
+	"44":  {9, 10, true},   // United Kingdom
+	"49":  {6, 13, true},   // Germany
+	"52":  {10, 10, false}, // Mexico
+	"55":  {10, 11, false}, // Brazil
+	"60":  {8, 10, true},   // Malaysia
+	"61":  {9, 9, true},    // Australia
+	"62":  {8, 12, true},   // Indonesia
+	"63":  {10, 10, true},  // Philippines
+	"65":  {8, 8, false},   // Singapore
+	"66":  {8, 9, true},    // Thailand
+	"81":  {9, 10, true},   // Japan
+	"84":  {9, 10, true},   // Vietnam
+	"86":  {10, 11, true},  // China
+	"90":  {10, 10, true},  // Turkey
+	"91":  {10, 10, true},  // India
+	"92":  {10, 10, true},  // Pakistan
+	"94":  {9, 9, true},    // Sri Lanka
+	"234": {8, 10, true},   // Nigeria
+	"254": {9, 9, true},    // Kenya
+	"880": {10, 10, true},  // Bangladesh
+	"966": {9, 9, true},    // Saudi Arabia
+	"971": {8, 9, true},    // United Arab Emirates
+	"977": {8, 10, false},  // Nepal
+}
+
+// makeCodeSet builds a set from whitespace-separated codes
+func makeCodeSet(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}
+
+// splitCountryCode returns the country calling code a digit string starts with
+// and the remaining national number, or empty strings if there is none
+func splitCountryCode(digits string) (code, national string) {
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		if countryCallingCodes[digits[:n]] {
+			return digits[:n], digits[n:]
+		}
+	}
+	return "", ""
+}
+
+// canonicalPhoneNumber converts a phone number written in international format
+// ("+880 1712-345678", "00 44 7911 123456", "12025550123") to E.164 digits without
+// the '+'. It returns "" when the number has no valid country code or its length
+// doesn't fit the country's numbering plan.
+func canonicalPhoneNumber(input string) string {
+	var sb strings.Builder
+	for _, r := range input {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+	digits := sb.String()
+
+	// "00" is the international call prefix in most countries, equivalent to '+'
+	digits = strings.TrimPrefix(digits, "00")
+
+	code, national := splitCountryCode(digits)
+	if code == "" {
+		return ""
+	}
+
+	rule, strict := nationalNumberRules[code]
+	if strict && rule.trunkZero && strings.HasPrefix(national, "0") {
+		// Tolerate the domestic trunk prefix, e.g. "+44 (0)7911 123456"
+		national = national[1:]
+	}
+	if strict && (len(national) < rule.minLen || len(national) > rule.maxLen) {
+		return ""
+	}
+	if national == "" || len(code)+len(national) > maxE164Digits {
+		return ""
+	}
+
+	return code + national
+}
@@ -0,0 +1,92 @@
+package validation
+
+import "testing"
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // "" when the number should be rejected
+	}{
+		// United States
+		{"US with plus and spaces", "+1 202 555 0123", "12025550123"},
+		{"US with plus and punctuation", "+1 (202) 555-0123", "12025550123"},
+		{"US without plus", "12025550123", "12025550123"},
+		{"US with international prefix", "001 202 555 0123", "12025550123"},
+
+		// United Kingdom
+		{"UK with plus and spaces", "+44 7911 123456", "447911123456"},
+		{"UK without plus", "447911123456", "447911123456"},
+		{"UK with trunk zero", "+44 (0)7911 123456", "447911123456"},
+		{"UK with international prefix", "00 44 7911 123456", "447911123456"},
+
+		// Bangladesh
+		{"Bangladesh with plus and spaces", "+880 1712 345678", "8801712345678"},
+		{"Bangladesh with plus and dash", "+880 1712-345678", "8801712345678"},
+		{"Bangladesh without plus", "8801712345678", "8801712345678"},
+		{"Bangladesh with trunk zero", "+880 01712 345678", "8801712345678"},
+
+		// Invalid country codes
+		{"unassigned country code", "+999 1234 567890", ""},
+		{"leading zero country code", "+0 202 555 0123", ""},
+		{"national format without country code", "01712345678", ""},
+
+		// Wrong lengths
+		{"US too short", "+1 202 555 012", ""},
+		{"US too long", "+1 202 555 01234", ""},
+		{"UK too short", "+44 7911 1234", ""},
+		{"UK too long", "+44 7911 1234567", ""},
+		{"Bangladesh too short", "+880 1712 34567", ""},
+		{"Bangladesh too long", "+880 1712 3456789", ""},
+
+		// Not a number
+		{"empty", "", ""},
+		{"letters", "not a number", ""},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, appErr := v.NormalizePhoneNumber(tt.input)
+			if tt.want == "" {
+				if appErr == nil {
+					t.Fatalf("NormalizePhoneNumber(%q) = %q, want an error", tt.input, got)
+				}
+				return
+			}
+			if appErr != nil {
+				t.Fatalf("NormalizePhoneNumber(%q) returned error: %s", tt.input, appErr.Message)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhoneNumber(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeJIDFromPhoneNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"+1 202 555 0123", "12025550123@s.whatsapp.net"},
+		{"+44 7911 123456", "447911123456@s.whatsapp.net"},
+		{"+880 1712-345678", "8801712345678@s.whatsapp.net"},
+		{"12025550123@s.whatsapp.net", "12025550123@s.whatsapp.net"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		got, appErr := v.NormalizeJID(tt.input)
+		if appErr != nil {
+			t.Fatalf("NormalizeJID(%q) returned error: %s", tt.input, appErr.Message)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeJID(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if got, appErr := v.NormalizeJID("+999 1234 567890"); appErr == nil {
+		t.Errorf("NormalizeJID with an unassigned country code = %q, want an error", got)
+	}
+}
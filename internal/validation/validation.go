@@ -1,8 +1,10 @@
 package validation
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/nahidhasan98/whatsapp-notifier/internal/errors"
 	"github.com/nahidhasan98/whatsapp-notifier/internal/models"
@@ -27,14 +29,47 @@ var (
 
 	// newsletter pattern: number@newsletter
 	newsletterPattern = regexp.MustCompile(`^\d+@newsletter$`)
+
+	// Device JID pattern: user[.agent][:device]@server (e.g., 1234567890.0:12@s.whatsapp.net)
+	deviceJIDPattern = regexp.MustCompile(`^(\d+)(?:\.\d+)?(?::\d+)?@(s\.whatsapp\.net|c\.us|lid)$`)
 )
 
 // Validator provides validation methods
-type Validator struct{}
+type Validator struct {
+	stripDeviceSuffix bool   // Strip device/agent suffixes when normalizing JIDs
+	messageFooter     string // Appended to text messages, counted against the length limit
+}
 
 // New creates a new validator instance
 func New() *Validator {
-	return &Validator{}
+	return &Validator{
+		stripDeviceSuffix: true,
+	}
+}
+
+// SetStripDeviceSuffix controls whether NormalizeJID strips device/agent suffixes
+func (v *Validator) SetStripDeviceSuffix(enabled bool) {
+	v.stripDeviceSuffix = enabled
+}
+
+// SetMessageFooter sets the footer appended to text messages, so message length checks include it
+func (v *Validator) SetMessageFooter(footer string) {
+	v.messageFooter = footer
+}
+
+// maxMessageLength is the maximum length of a text message in bytes, including any footer
+const maxMessageLength = 4096
+
+// CheckMessageLength checks a message fits the length limit once the footer is appended.
+// Handlers that add to a validated message re-check it with this.
+func (v *Validator) CheckMessageLength(message string, omitFooter bool) *errors.AppError {
+	if len(message) > maxMessageLength {
+		return errors.ValidationError(fmt.Sprintf("Message too long (maximum %d characters)", maxMessageLength))
+	}
+	if v.messageFooter != "" && !omitFooter && len(message)+len("\n\n")+len(v.messageFooter) > maxMessageLength {
+		return errors.ValidationError(fmt.Sprintf("Message too long with the footer (maximum %d characters including MESSAGE_FOOTER); shorten it or set 'omit_footer'", maxMessageLength))
+	}
+	return nil
 }
 
 // ValidateSendMessageRequest validates a send message request
@@ -57,13 +92,173 @@ func (v *Validator) ValidateSendMessageRequest(req *models.SendMessageRequest) *
 		return errors.ValidationError("'message' field is required")
 	}
 
-	if len(req.Message) > 4096 {
-		return errors.ValidationError("Message too long (maximum 4096 characters)")
+	if appErr := v.CheckMessageLength(req.Message, req.OmitFooter); appErr != nil {
+		return appErr
+	}
+
+	// Validate 'priority' field
+	if !req.Priority.IsValid() {
+		return errors.ValidationError("Invalid priority (must be one of: low, normal, urgent)")
+	}
+
+	// Validate quoted message fields
+	if (req.QuotedMessageID == "") != (req.QuotedJID == "") {
+		return errors.ValidationError("'quoted_message_id' and 'quoted_jid' must be provided together")
+	}
+	if req.QuotedJID != "" && !v.IsValidJID(req.QuotedJID) {
+		return errors.InvalidJID(req.QuotedJID)
+	}
+
+	// Validate 'disappearing_timer' field
+	if req.DisappearingTimer != nil {
+		switch *req.DisappearingTimer {
+		case 0, 86400, 604800, 7776000:
+		default:
+			return errors.ValidationError("Invalid disappearing_timer (must be one of: 0, 86400, 604800, 7776000)")
+		}
 	}
 
 	return nil
 }
 
+// ValidateSendGroupMessageRequest validates a group message request with @mentions
+func (v *Validator) ValidateSendGroupMessageRequest(req *models.SendGroupMessageRequest) *errors.AppError {
+	if req == nil {
+		return errors.InvalidRequest("Request body is required")
+	}
+
+	if !groupJIDPattern.MatchString(strings.TrimSpace(req.To)) {
+		return errors.ValidationError("'to' must be a group JID (e.g. 120363025343298765@g.us)")
+	}
+
+	if strings.TrimSpace(req.Message) == "" {
+		return errors.ValidationError("'message' field is required")
+	}
+
+	if appErr := v.CheckMessageLength(req.Message, req.OmitFooter); appErr != nil {
+		return appErr
+	}
+
+	for _, mention := range req.Mentions {
+		if !individualJIDPattern.MatchString(mention) {
+			return errors.ValidationError(fmt.Sprintf("Invalid mention '%s' (must be an individual JID like 1234567890@s.whatsapp.net)", mention))
+		}
+
+		// WhatsApp only renders a tag where the text references the number
+		number := strings.TrimSuffix(mention, "@s.whatsapp.net")
+		if !strings.Contains(req.Message, "@"+number) {
+			return errors.ValidationError(fmt.Sprintf("Message must contain @%s for mention %s", number, mention))
+		}
+	}
+
+	if !req.Priority.IsValid() {
+		return errors.ValidationError("Invalid priority (must be one of: low, normal, urgent)")
+	}
+
+	return nil
+}
+
+// maxBulkRecipients is the maximum number of recipients in one bulk send
+const maxBulkRecipients = 100
+
+// ValidateSendBulkMessageRequest validates the shared fields of a bulk send request.
+// Recipients are validated individually so invalid ones can be skipped.
+func (v *Validator) ValidateSendBulkMessageRequest(req *models.SendBulkMessageRequest) *errors.AppError {
+	if req == nil {
+		return errors.InvalidRequest("Request body is required")
+	}
+
+	if len(req.To) == 0 || len(req.To) > maxBulkRecipients {
+		return errors.ValidationError(fmt.Sprintf("Provide between 1 and %d recipients in 'to'", maxBulkRecipients))
+	}
+
+	if strings.TrimSpace(req.Message) == "" {
+		return errors.ValidationError("'message' field is required")
+	}
+
+	if appErr := v.CheckMessageLength(req.Message, req.OmitFooter); appErr != nil {
+		return appErr
+	}
+
+	if !req.Priority.IsValid() {
+		return errors.ValidationError("Invalid priority (must be one of: low, normal, urgent)")
+	}
+
+	return nil
+}
+
+// ValidateSendReactionRequest validates a reaction request
+func (v *Validator) ValidateSendReactionRequest(req *models.SendReactionRequest) *errors.AppError {
+	if req == nil {
+		return errors.InvalidRequest("Request body is required")
+	}
+
+	if strings.TrimSpace(req.To) == "" {
+		return errors.ValidationError("'to' field is required")
+	}
+	if !v.IsValidJID(req.To) {
+		return errors.InvalidJID(req.To)
+	}
+
+	if strings.TrimSpace(req.MessageID) == "" {
+		return errors.ValidationError("'message_id' field is required")
+	}
+
+	if req.Sender != "" && !v.IsValidJID(req.Sender) {
+		return errors.InvalidJID(req.Sender)
+	}
+
+	if req.Emoji != "" && !isSingleGrapheme(req.Emoji) {
+		return errors.ValidationError("'emoji' must be a single emoji, or empty to remove the reaction")
+	}
+
+	return nil
+}
+
+// isSingleGrapheme reports whether s is one user-perceived character: a base rune
+// followed only by modifiers (combining marks, variation selectors, skin tones, tags,
+// keycaps) or zero-width-joined runes, or a regional-indicator flag pair
+func isSingleGrapheme(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 || isGraphemeExtender(runes[0]) || runes[0] == zeroWidthJoiner {
+		return false
+	}
+
+	if isRegionalIndicator(runes[0]) {
+		return len(runes) == 1 || (len(runes) == 2 && isRegionalIndicator(runes[1]))
+	}
+
+	for i := 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == zeroWidthJoiner:
+			// A joiner must be followed by the rune it joins
+			if i == len(runes)-1 || runes[i+1] == zeroWidthJoiner {
+				return false
+			}
+			i++
+		case !isGraphemeExtender(runes[i]):
+			return false
+		}
+	}
+	return true
+}
+
+// zeroWidthJoiner combines emoji into a single glyph (e.g. 👩‍💻)
+const zeroWidthJoiner = '\u200D'
+
+// isGraphemeExtender reports whether r modifies the preceding rune rather than starting a new character
+func isGraphemeExtender(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || // Variation selectors
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // Skin tone modifiers
+		(r >= 0xE0020 && r <= 0xE007F) // Tag sequences (subdivision flags)
+}
+
+// isRegionalIndicator reports whether r is one half of a flag emoji
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
 // IsValidJID checks if a JID is valid WhatsApp format
 func (v *Validator) IsValidJID(jid string) bool {
 	jid = strings.TrimSpace(jid)
@@ -101,6 +296,11 @@ func (v *Validator) IsValidJID(jid string) bool {
 	return false
 }
 
+// IsGroupJID checks if a JID is a group JID
+func (v *Validator) IsGroupJID(jid string) bool {
+	return groupJIDPattern.MatchString(jid)
+}
+
 // NormalizeJID normalizes a JID to proper WhatsApp format
 func (v *Validator) NormalizeJID(jid string) (string, *errors.AppError) {
 	jid = strings.TrimSpace(jid)
@@ -110,6 +310,13 @@ func (v *Validator) NormalizeJID(jid string) (string, *errors.AppError) {
 		return jid, nil
 	}
 
+	// Strip device/agent suffix to get the base user JID
+	if v.stripDeviceSuffix {
+		if baseJID := v.stripDevice(jid); baseJID != "" && v.IsValidJID(baseJID) {
+			return baseJID, nil
+		}
+	}
+
 	// Try to normalize phone number to individual JID
 	if phoneNumber := v.extractPhoneNumber(jid); phoneNumber != "" {
 		normalizedJID := phoneNumber + "@s.whatsapp.net"
@@ -121,13 +328,30 @@ func (v *Validator) NormalizeJID(jid string) (string, *errors.AppError) {
 	return "", errors.InvalidJID(jid)
 }
 
-// extractPhoneNumber extracts a phone number from various formats
+// stripDevice removes the agent and device parts from a JID (user.agent:device@server -> user@server)
+func (v *Validator) stripDevice(jid string) string {
+	matches := deviceJIDPattern.FindStringSubmatch(jid)
+	if matches == nil {
+		return ""
+	}
+	return matches[1] + "@" + matches[2]
+}
+
+// NormalizePhoneNumber returns the digits of a phone number in E.164 format
+// (country code included, no '+'), or an error if it has no valid country code
+// or is too short or long for the country
+func (v *Validator) NormalizePhoneNumber(phone string) (string, *errors.AppError) {
+	if normalized := v.extractPhoneNumber(phone); normalized != "" {
+		return normalized, nil
+	}
+	return "", errors.ValidationError("Invalid phone number (expected an international number with a valid country code)")
+}
+
+// extractPhoneNumber extracts a phone number from various formats, canonicalized to E.164 digits
 func (v *Validator) extractPhoneNumber(input string) string {
-	// Remove all non-digit characters
-	phoneRegex := regexp.MustCompile(`\D`)
-	phone := phoneRegex.ReplaceAllString(input, "")
+	phone := canonicalPhoneNumber(input)
 
-	// Check if it's a valid phone number length (10-15 digits)
+	// WhatsApp user JIDs are 10-15 digits long
 	if len(phone) >= 10 && len(phone) <= 15 {
 		return phone
 	}